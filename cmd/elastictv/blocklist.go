@@ -0,0 +1,76 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/shaunschembri/elastictv/pkg/elastictv"
+)
+
+// manageBlocklist lists, adds or deletes the entries of the blocklist.
+func manageBlocklist(estv *elastictv.ElasticTV, args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+
+	switch {
+	case args[0] == "list" && len(args) == 1:
+		entries, err := estv.BlocklistEntries()
+		if err != nil {
+			return err
+		}
+
+		for _, entry := range entries {
+			fmt.Fprintf(os.Stdout, "%s  %s  [ TMDb %d | IMDb %s ] %s  %s\n", entry.ID, entry.Type,
+				entry.IDs.TMDb, entry.IDs.IMDb, entry.Pattern, entry.Reason)
+		}
+
+		return nil
+	case args[0] == "add":
+		return addBlocklistEntry(estv, args[1:])
+	case args[0] == "delete" && len(args) == 2:
+		return estv.DeleteBlocklistEntry(args[1])
+	default:
+		return errUsage
+	}
+}
+
+func addBlocklistEntry(estv *elastictv.ElasticTV, args []string) error {
+	flags := flag.NewFlagSet("blocklist", flag.ContinueOnError)
+	docType := flags.String("type", "", "type of blocked titles (movie or tv), required with -tmdb and any type if not set")
+	tmdbID := flags.Int("tmdb", 0, "TMDb ID of the blocked title")
+	imdbID := flags.String("imdb", "", "IMDb ID of the blocked title")
+	pattern := flags.String("pattern", "", "regular expression matching blocked titles")
+	reason := flags.String("reason", "", "reason the titles are blocked")
+
+	if err := flags.Parse(args); err != nil || flags.NArg() > 0 {
+		return errUsage
+	}
+
+	// Movies and tv shows share the same TMDb IDs
+	if *tmdbID > 0 && *docType == "" {
+		return fmt.Errorf("-type is required with -tmdb")
+	}
+
+	entry := elastictv.BlocklistEntry{
+		IDs:     elastictv.IDs{TMDb: *tmdbID, IMDb: *imdbID},
+		Pattern: *pattern,
+		Reason:  *reason,
+	}
+
+	if *docType != "" {
+		if err := entry.Type.UnmarshalText([]byte(*docType)); err != nil {
+			return err
+		}
+	}
+
+	entry, err := estv.AddBlocklistEntry(entry)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stdout, "%s\n", entry.ID)
+
+	return nil
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/shaunschembri/elastictv/pkg/elastictv"
+)
+
+const maxSearchTitles = 5
+
+// searchHistory lists, purges or expires the search items matching the flags.
+func searchHistory(estv *elastictv.ElasticTV, args []string) error {
+	action := "list"
+	if len(args) > 0 && (args[0] == "list" || args[0] == "purge" || args[0] == "expire") {
+		action, args = args[0], args[1:]
+	}
+
+	flags := flag.NewFlagSet("history", flag.ContinueOnError)
+	docType := flags.String("type", "", "type of searches (movie, tv, episode or season)")
+	attribute := flags.String("attribute", "", "attribute of searches (ex title or imdb_id)")
+	since := flags.Duration("since", 0, "only searches which ran within this duration (ex 24h)")
+	limit := flags.Int("limit", 0, "maximum number of searches listed")
+	titles := flags.Bool("titles", false, "list the titles each search produced")
+	all := flags.Bool("all", false, "purge or expire all searches when no other filter is set")
+
+	if err := flags.Parse(args); err != nil || flags.NArg() > 0 {
+		return errUsage
+	}
+
+	filter := elastictv.SearchHistoryFilter{Size: *limit, All: *all}
+
+	if *docType != "" {
+		if err := filter.Type.UnmarshalText([]byte(*docType)); err != nil {
+			return err
+		}
+	}
+
+	if *attribute != "" {
+		if err := filter.Attribute.UnmarshalText([]byte(*attribute)); err != nil {
+			return err
+		}
+	}
+
+	if *since > 0 {
+		filter.From = time.Now().Add(-*since)
+	}
+
+	if (action == "purge" || action == "expire") && *docType == "" && *attribute == "" && *since == 0 && !*all {
+		return fmt.Errorf("%w: set -type, -attribute or -since, or -all to %s every search",
+			elastictv.ErrUnfilteredHistory, action)
+	}
+
+	switch action {
+	case "purge":
+		deleted, err := estv.PurgeSearchHistory(filter)
+		fmt.Fprintf(os.Stdout, "%d searches purged\n", deleted)
+
+		return err
+	case "expire":
+		expired, err := estv.ExpireSearchHistory(filter)
+		fmt.Fprintf(os.Stdout, "%d searches expired\n", expired)
+
+		return err
+	}
+
+	entries, err := estv.SearchHistory(filter)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		status := "expires " + entry.ExpiresAt.Format(time.DateOnly)
+		if entry.Expired {
+			status = "expired"
+		}
+
+		fmt.Fprintf(os.Stdout, "%s  %s  [ %s ]\n", entry.Timestamp, entry.SearchItem, status)
+
+		if !*titles {
+			continue
+		}
+
+		found, err := estv.SearchItemTitles(entry.SearchItem, maxSearchTitles)
+		if err != nil {
+			return err
+		}
+
+		for _, title := range found {
+			fmt.Fprintf(os.Stdout, "    %s (%d) %s [ TMDb %d | IMDb %s ]\n",
+				title.Title, title.Year, title.Type, title.IDs.TMDb, title.IDs.IMDb)
+		}
+	}
+
+	return nil
+}
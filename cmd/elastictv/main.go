@@ -0,0 +1,148 @@
+// Command elastictv runs maintenance operations on the ElasticTV indices.
+//
+// Usage:
+//
+//	elastictv [-config file] sync <tmdb id>...
+//	elastictv [-config file] history [list|purge|expire] [-type type] [-attribute attribute] [-since duration] [-limit n] [-titles] [-all]
+//	elastictv [-config file] overrides list|export|import <file>|delete <id>
+//	elastictv [-config file] blocklist list|add [-type type] [-tmdb id] [-imdb id] [-pattern regexp] [-reason text]|delete <id>
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/spf13/viper"
+
+	"github.com/shaunschembri/elastictv/pkg/elastictv"
+	"github.com/shaunschembri/elastictv/pkg/elastictv/omdb"
+	"github.com/shaunschembri/elastictv/pkg/elastictv/tmdb"
+	"github.com/shaunschembri/elastictv/pkg/elastictv/trakt"
+	"github.com/shaunschembri/elastictv/pkg/elastictv/tvmaze"
+)
+
+var errUsage = errors.New("usage: elastictv [-config file] sync <tmdb id>... | " +
+	"history [list|purge|expire] [-type type] [-attribute attribute] [-since duration] [-limit n] [-titles] [-all] | " +
+	"overrides list|export|import <file>|delete <id> | " +
+	"blocklist list|add [-type type] [-tmdb id] [-imdb id] [-pattern regexp] [-reason text]|delete <id>")
+
+func main() {
+	configFile := flag.String("config", "elastictv.yaml", "configuration file")
+	flag.Parse()
+
+	if err := run(*configFile, flag.Args()); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(configFile string, args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+
+	viper.SetConfigFile(configFile)
+	if err := viper.ReadInConfig(); err != nil {
+		return fmt.Errorf("error reading configuration: %w", err)
+	}
+
+	switch args[0] {
+	case "sync":
+		estv, err := newElasticTV()
+		if err != nil {
+			return err
+		}
+
+		return syncTVShows(estv, args[1:])
+	case "history":
+		// The search history is read from the search index only so providers are not needed
+		estv, err := elastictv.New()
+		if err != nil {
+			return err
+		}
+
+		return searchHistory(estv, args[1:])
+	case "overrides":
+		estv, err := elastictv.New()
+		if err != nil {
+			return err
+		}
+
+		return manageOverrides(estv, args[1:])
+	case "blocklist":
+		estv, err := elastictv.New()
+		if err != nil {
+			return err
+		}
+
+		return manageBlocklist(estv, args[1:])
+	default:
+		return errUsage
+	}
+}
+
+// newElasticTV returns ElasticTV with the providers which are configured, with TMDb always
+// added first since it is needed to sync tv shows.
+func newElasticTV() (*elastictv.ElasticTV, error) {
+	estv, err := elastictv.New()
+	if err != nil {
+		return nil, err
+	}
+
+	providers := []elastictv.SearchableProvider{tmdb.TMDb{}}
+
+	if viper.IsSet("elastictv.provider.tvmaze") {
+		providers = append(providers, tvmaze.TVmaze{})
+	}
+
+	if viper.IsSet("elastictv.provider.trakt.client_id") {
+		providers = append(providers, trakt.Trakt{})
+	}
+
+	if viper.IsSet("elastictv.provider.omdb.api_key") {
+		providers = append(providers, omdb.OMDb{})
+	}
+
+	for _, provider := range providers {
+		if err := estv.AddProvider(provider); err != nil {
+			return nil, err
+		}
+	}
+
+	return estv, nil
+}
+
+func syncTVShows(estv *elastictv.ElasticTV, args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+
+	failed := false
+
+	for _, arg := range args {
+		tmdbID, err := strconv.Atoi(arg)
+		if err != nil {
+			return fmt.Errorf("invalid TMDb ID [ %s ]: %w", arg, err)
+		}
+
+		report, err := estv.SyncTVShow(tmdbID)
+		if report != nil {
+			fmt.Fprintln(os.Stdout, report)
+		}
+
+		if err != nil {
+			log.Printf("error syncing tvshow with TMDb ID %d: %s", tmdbID, err)
+
+			failed = true
+		}
+	}
+
+	if failed {
+		return errors.New("some tv shows failed to sync")
+	}
+
+	return nil
+}
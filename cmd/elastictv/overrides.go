@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/shaunschembri/elastictv/pkg/elastictv"
+)
+
+// manageOverrides lists, exports, imports or deletes the overrides of lookups.
+func manageOverrides(estv *elastictv.ElasticTV, args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+
+	switch {
+	case args[0] == "list" && len(args) == 1:
+		overrides, err := estv.Overrides()
+		if err != nil {
+			return err
+		}
+
+		for _, override := range overrides {
+			fmt.Fprintf(os.Stdout, "%s  %s  %s (%d) %s => [ TMDb %d | IMDb %s ]\n", override.ID, override.Type,
+				override.Title, override.Year, override.Pattern, override.IDs.TMDb, override.IDs.IMDb)
+		}
+
+		return nil
+	case args[0] == "export" && len(args) == 1:
+		return estv.ExportOverrides(os.Stdout)
+	case args[0] == "import" && len(args) == 2:
+		file, err := os.Open(args[1])
+		if err != nil {
+			return fmt.Errorf("error opening overrides: %w", err)
+		}
+		defer file.Close()
+
+		imported, err := estv.ImportOverrides(file)
+		fmt.Fprintf(os.Stdout, "%d overrides imported\n", imported)
+
+		return err
+	case args[0] == "delete" && len(args) == 2:
+		return estv.DeleteOverride(args[1])
+	default:
+		return errUsage
+	}
+}
@@ -0,0 +1,235 @@
+package elastictv
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+)
+
+const (
+	// maxBlocklistEntries is the maximum number of blocklist entries, which are all read when
+	// the blocklist is loaded.
+	maxBlocklistEntries = 10000
+	// maxBlockedCandidates is the number of best matches of a lookup which are checked for a
+	// title which is not blocked.
+	maxBlockedCandidates = 10
+)
+
+var errNoBlocklistIndex = errors.New("blocklist index is not configured")
+
+// BlocklistEntry excludes titles from lookups and from being indexed by providers, such as fan
+// edits or duplicates which keep matching lookups they should not.
+type BlocklistEntry struct {
+	ID string `json:"-"`
+	// Type of titles which are blocked, or any type if not set
+	Type Type `json:"type,omitempty"`
+	// IDs blocks titles having any of the IDs which are set
+	IDs IDs `json:"ids"`
+	// Pattern is a regular expression which blocks titles whose title or any alias matches it
+	Pattern   string `json:"pattern,omitempty"`
+	Reason    string `json:"reason,omitempty"`
+	Timestamp string `json:"@timestamp"`
+
+	pattern *regexp.Regexp
+}
+
+func (b BlocklistEntry) validate() error {
+	if b.IDs == (IDs{}) && b.Pattern == "" {
+		return fmt.Errorf("blocklist entry has neither IDs nor a title pattern")
+	}
+
+	// Movies and tv shows share the same TMDb, Trakt and TVDb IDs
+	if b.Type == 0 && (b.IDs.TMDb > 0 || b.IDs.Trakt > 0 || b.IDs.TVDb > 0) {
+		return fmt.Errorf("blocklist entry with a TMDb, Trakt or TVDb ID has no type")
+	}
+
+	if _, err := regexp.Compile(b.Pattern); err != nil {
+		return fmt.Errorf("invalid title pattern of blocklist entry [ %s ]: %w", b.Pattern, err)
+	}
+
+	return nil
+}
+
+// key identifies blocklist entries blocking the same titles, so adding an entry replaces any
+// entry of the same type, IDs and pattern.
+func (b BlocklistEntry) key() string {
+	sum := sha1.Sum([]byte(fmt.Sprintf("%d|%+v|%s", b.Type, b.IDs, b.Pattern)))
+
+	return hex.EncodeToString(sum[:])
+}
+
+func (b BlocklistEntry) blocks(title Title) bool {
+	if b.Type > 0 && b.Type != title.Type {
+		return false
+	}
+
+	if b.IDs != (IDs{}) && b.hasAnyID(title.IDs) {
+		return true
+	}
+
+	if b.pattern == nil {
+		return false
+	}
+
+	for _, name := range append([]string{title.Title}, title.Alias...) {
+		if b.pattern.MatchString(name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (b BlocklistEntry) hasAnyID(ids IDs) bool {
+	return (b.IDs.TMDb > 0 && b.IDs.TMDb == ids.TMDb) ||
+		(b.IDs.IMDb != "" && b.IDs.IMDb == ids.IMDb) ||
+		(b.IDs.TVDb > 0 && b.IDs.TVDb == ids.TVDb) ||
+		(b.IDs.TVmaze > 0 && b.IDs.TVmaze == ids.TVmaze) ||
+		(b.IDs.Trakt > 0 && b.IDs.Trakt == ids.Trakt) ||
+		(b.IDs.Wikidata != "" && b.IDs.Wikidata == ids.Wikidata) ||
+		(b.IDs.EIDR != "" && b.IDs.EIDR == ids.EIDR)
+}
+
+// AddBlocklistEntry adds an entry to the blocklist, replacing any entry blocking the same titles.
+// Titles which are already cached are no longer returned by lookups.
+func (estv ElasticTV) AddBlocklistEntry(entry BlocklistEntry) (BlocklistEntry, error) {
+	if estv.Index.Blocklist == "" {
+		return entry, errNoBlocklistIndex
+	}
+
+	if err := entry.validate(); err != nil {
+		return entry, err
+	}
+
+	entry.ID = entry.key()
+	entry.Timestamp = CurrentTimestamp()
+
+	if err := estv.index(estv.Index.Blocklist, entry.ID, entry); err != nil {
+		return entry, err
+	}
+
+	estv.resetBlocklist()
+
+	return entry, estv.RefreshIndices(estv.Index.Blocklist)
+}
+
+// DeleteBlocklistEntry deletes the blocklist entry with the given ID.
+func (estv ElasticTV) DeleteBlocklistEntry(id string) error {
+	if estv.Index.Blocklist == "" {
+		return errNoBlocklistIndex
+	}
+
+	if err := estv.BulkIndex(estv.Index.Blocklist, []BulkDocument{{ID: id, Delete: true}}); err != nil {
+		return err
+	}
+
+	estv.resetBlocklist()
+
+	return estv.RefreshIndices(estv.Index.Blocklist)
+}
+
+// BlocklistEntries lists all blocklist entries.
+func (estv ElasticTV) BlocklistEntries() ([]BlocklistEntry, error) {
+	if estv.Index.Blocklist == "" {
+		return nil, errNoBlocklistIndex
+	}
+
+	result, err := estv.search(NewQuery().SortByTimestamp(true), estv.Index.Blocklist, maxBlocklistEntries)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]BlocklistEntry, 0, len(result.Hits.Hits))
+
+	for _, hit := range result.Hits.Hits {
+		entry := BlocklistEntry{ID: hit.ID}
+		if err := json.Unmarshal(hit.Source, &entry); err != nil {
+			return nil, fmt.Errorf("error parsing source: %w", err)
+		}
+
+		if entry.Pattern != "" {
+			if entry.pattern, err = regexp.Compile(entry.Pattern); err != nil {
+				return nil, fmt.Errorf("invalid title pattern of blocklist entry [ %s ]: %w", entry.Pattern, err)
+			}
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// IsBlocked reports whether a title is excluded by the blocklist. Titles are not blocked when
+// the blocklist cannot be read.
+func (estv ElasticTV) IsBlocked(title Title) bool {
+	entries, err := estv.getBlocklist()
+	if err != nil {
+		log.Printf("Error reading blocklist, title [ %s ] is not checked: %s", title.Title, err)
+
+		return false
+	}
+
+	for _, entry := range entries {
+		if entry.blocks(title) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getBlocklist returns the blocklist entries, which are cached by ElasticTV created by New until
+// they change or are older than the cache TTL, so entries added by other processes are loaded.
+func (estv ElasticTV) getBlocklist() ([]BlocklistEntry, error) {
+	if estv.Index.Blocklist == "" {
+		return nil, nil
+	}
+
+	if estv.blocklist == nil {
+		return estv.BlocklistEntries()
+	}
+
+	return estv.blocklist.get(estv.BlocklistEntries)
+}
+
+func (estv ElasticTV) resetBlocklist() {
+	if estv.blocklist != nil {
+		estv.blocklist.reset()
+	}
+}
+
+// getUnblockedTitle gets the best match of the query which is not blocked, returning a score
+// of 0 when there is none.
+func (estv ElasticTV) getUnblockedTitle(query *Query, title *Title) (float64, error) {
+	if estv.Index.Blocklist == "" {
+		return estv.getRecordWithScore(query, estv.Index.Title, title)
+	}
+
+	result, err := estv.search(query, estv.Index.Title, maxBlockedCandidates)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, hit := range result.Hits.Hits {
+		candidate := Title{}
+		if err := json.Unmarshal(hit.Source, &candidate); err != nil {
+			return 0, fmt.Errorf("error parsing source: %w", err)
+		}
+
+		if estv.IsBlocked(candidate) {
+			continue
+		}
+
+		if title != nil {
+			*title = candidate
+		}
+
+		return hit.Score, nil
+	}
+
+	return 0, nil
+}
@@ -0,0 +1,67 @@
+package elastictv
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBlocklistEntryValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		entry BlocklistEntry
+		valid bool
+	}{
+		{"imdb id", BlocklistEntry{IDs: IDs{IMDb: "tt0133093"}}, true},
+		{"pattern", BlocklistEntry{Pattern: "(?i)fan edit"}, true},
+		{"tmdb id with type", BlocklistEntry{Type: MovieType, IDs: IDs{TMDb: 603}}, true},
+		{"tmdb id", BlocklistEntry{IDs: IDs{TMDb: 603}}, false},
+		{"trakt id", BlocklistEntry{IDs: IDs{Trakt: 481}}, false},
+		{"tvdb id", BlocklistEntry{IDs: IDs{TVDb: 81189}}, false},
+		{"empty", BlocklistEntry{Reason: "duplicate"}, false},
+		{"invalid pattern", BlocklistEntry{Pattern: "("}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.entry.validate(); (err == nil) != tt.valid {
+				t.Errorf("validate() = %v, want valid %t", err, tt.valid)
+			}
+		})
+	}
+}
+
+func TestBulkUpsertTitlesSkipsBlocked(t *testing.T) {
+	estv, requests := newTestElasticTV(t, func(request esRequest) string {
+		switch request.Path {
+		case "/blocklist/_search":
+			return `{"hits":{"total":{"value":1},"hits":[{"_id":"1","_source":{"ids":{"imdb":"tt0000001"}}}]}}`
+		case "/titles/_bulk":
+			return `{"errors":false,"items":[]}`
+		default:
+			return noHits
+		}
+	})
+
+	titles := []Title{
+		{Title: "The Matrix", Type: MovieType, IDs: IDs{IMDb: "tt0133093"}, Source: "IMDb"},
+		{Title: "The Matrix (Fan Edit)", Type: MovieType, IDs: IDs{IMDb: "tt0000001"}, Source: "IMDb"},
+	}
+
+	if err := estv.BulkUpsertTitles(titles); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, request := range *requests {
+		if request.Path != "/titles/_bulk" {
+			continue
+		}
+
+		if !strings.Contains(request.Body, "tt0133093") || strings.Contains(request.Body, "tt0000001") {
+			t.Errorf("bulk request %s does not skip the blocked title", request.Body)
+		}
+
+		return
+	}
+
+	t.Error("titles were not indexed")
+}
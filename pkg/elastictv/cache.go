@@ -0,0 +1,60 @@
+package elastictv
+
+import (
+	"sync"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// defaultCacheTTL is how long cached records are used unless elastictv.cache_ttl is set, after
+// which they are loaded again to pick up changes made by other processes.
+const defaultCacheTTL = 5 * time.Minute
+
+// cache holds records read from an index, which are loaded again after they change or once they
+// are older than the cache TTL.
+type cache[T any] struct {
+	mutex    sync.RWMutex
+	records  []T
+	loadedAt time.Time
+}
+
+// get returns the cached records, calling load when they are not loaded or have expired.
+func (c *cache[T]) get(load func() ([]T, error)) ([]T, error) {
+	c.mutex.RLock()
+	if !c.loadedAt.IsZero() && time.Since(c.loadedAt) < cacheTTL() {
+		defer c.mutex.RUnlock()
+
+		return c.records, nil
+	}
+	c.mutex.RUnlock()
+
+	records, err := load()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.records = records
+	c.loadedAt = time.Now()
+
+	return records, nil
+}
+
+func (c *cache[T]) reset() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.records = nil
+	c.loadedAt = time.Time{}
+}
+
+func cacheTTL() time.Duration {
+	if viper.IsSet("elastictv.cache_ttl") {
+		return viper.GetDuration("elastictv.cache_ttl")
+	}
+
+	return defaultCacheTTL
+}
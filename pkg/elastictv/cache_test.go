@@ -0,0 +1,57 @@
+package elastictv
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func TestCache(t *testing.T) {
+	loads := 0
+	load := func() ([]string, error) {
+		loads++
+
+		return []string{"record"}, nil
+	}
+
+	c := &cache[string]{}
+
+	for i := 0; i < 2; i++ {
+		if records, err := c.get(load); err != nil || len(records) != 1 {
+			t.Fatalf("get = %v, %v", records, err)
+		}
+	}
+
+	if loads != 1 {
+		t.Errorf("loaded %d times, want 1", loads)
+	}
+
+	c.reset()
+
+	if _, err := c.get(load); err != nil || loads != 2 {
+		t.Errorf("loaded %d times after a reset, want 2", loads)
+	}
+
+	viper.Set("elastictv.cache_ttl", time.Nanosecond)
+	t.Cleanup(viper.Reset)
+	time.Sleep(time.Millisecond)
+
+	if _, err := c.get(load); err != nil || loads != 3 {
+		t.Errorf("loaded %d times after the ttl, want 3", loads)
+	}
+}
+
+func TestCacheLoadError(t *testing.T) {
+	errLoad := errors.New("load failed")
+	c := &cache[string]{}
+
+	if _, err := c.get(func() ([]string, error) { return nil, errLoad }); !errors.Is(err, errLoad) {
+		t.Errorf("get error = %v, want %v", err, errLoad)
+	}
+
+	if records, err := c.get(func() ([]string, error) { return []string{"record"}, nil }); err != nil || len(records) != 1 {
+		t.Errorf("failed load was cached, get = %v, %v", records, err)
+	}
+}
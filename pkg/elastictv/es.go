@@ -7,57 +7,76 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"log"
+	"strings"
 	"time"
 
 	"github.com/elastic/go-elasticsearch/v8/esapi"
+	"github.com/hashicorp/go-multierror"
 )
 
-const timeFormat = "2006-01-02T15:04:05.0000000"
+const (
+	timeFormat = "2006-01-02T15:04:05.0000000"
+	// ExpiredTimestamp is older than any update_after_days, so documents written with it are
+	// refreshed from the providers the next time they are looked up.
+	ExpiredTimestamp = "1970-01-01T00:00:00.0000000"
+	// maxBulkRecords is the maximum number of indexed documents read to merge a bulk request
+	maxBulkRecords = 10000
+)
 
 func (estv ElasticTV) queryES(query *Query, index string, doc interface{}) (string, float64, error) {
-	buf, err := estv.encodeQuery(query)
+	esDoc, err := estv.search(query, index, 1)
 	if err != nil {
 		return "", 0, err
 	}
 
+	if esDoc.Hits.Total.Value == 0 || len(esDoc.Hits.Hits) == 0 {
+		return "", 0, nil
+	}
+
+	if doc != nil {
+		if err := json.Unmarshal(esDoc.Hits.Hits[0].Source, doc); err != nil {
+			return "", 0, fmt.Errorf("error parsing source: %w", err)
+		}
+	}
+
+	return esDoc.Hits.Hits[0].ID, esDoc.Hits.Hits[0].Score, nil
+}
+
+func (estv ElasticTV) search(query *Query, index string, size int) (*esResult, error) {
+	buf, err := estv.encodeQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
 	response, err := estv.Client.Search(
 		estv.Client.Search.WithContext(context.Background()),
 		estv.Client.Search.WithFrom(0),
-		estv.Client.Search.WithSize(1),
+		estv.Client.Search.WithSize(size),
 		estv.Client.Search.WithIndex(index),
 		estv.Client.Search.WithBody(buf),
 	)
 	if err != nil {
 		buf, _ := estv.encodeQuery(query)
 
-		return "", 0, fmt.Errorf("error querying elasticsearch: Error: %w Query: %s",
+		return nil, fmt.Errorf("error querying elasticsearch: Error: %w Query: %s",
 			err, buf.String())
 	}
 	defer response.Body.Close()
 
-	esDoc := esResult{}
-	if err := json.NewDecoder(response.Body).Decode(&esDoc); err != nil {
-		return "", 0, fmt.Errorf("error parsing reply: %w", err)
+	esDoc := &esResult{}
+	if err := json.NewDecoder(response.Body).Decode(esDoc); err != nil {
+		return nil, fmt.Errorf("error parsing reply: %w", err)
 	}
 
 	if esDoc.Error.Reason != "" {
 		buf, _ := estv.encodeQuery(query)
 
-		return "", 0, fmt.Errorf("error of type [%s] return from elasticsearch: Error %s Query: %s",
+		return nil, fmt.Errorf("error of type [%s] return from elasticsearch: Error %s Query: %s",
 			esDoc.Error.Type, esDoc.Error.Reason, buf.String())
 	}
 
-	if esDoc.Hits.Total.Value == 0 {
-		return "", 0, nil
-	}
-
-	if doc != nil {
-		if err := json.Unmarshal(esDoc.Hits.Hits[0].Source, doc); err != nil {
-			return "", 0, fmt.Errorf("error parsing source: %w", err)
-		}
-	}
-
-	return esDoc.Hits.Hits[0].ID, esDoc.Hits.Hits[0].Score, nil
+	return esDoc, nil
 }
 
 func (estv ElasticTV) encodeQuery(query *Query) (*bytes.Buffer, error) {
@@ -98,6 +117,80 @@ func (estv ElasticTV) index(index, docID string, doc interface{}) error {
 	return nil
 }
 
+func (estv ElasticTV) BulkIndex(index string, docs []BulkDocument) error {
+	if len(docs) == 0 {
+		return nil
+	}
+
+	var buf bytes.Buffer
+
+	encoder := json.NewEncoder(&buf)
+	for _, doc := range docs {
+		actionType := "index"
+		if doc.Delete {
+			actionType = "delete"
+		}
+
+		action := map[string]map[string]string{actionType: {}}
+		if doc.ID != "" {
+			action[actionType]["_id"] = doc.ID
+		}
+
+		if err := encoder.Encode(action); err != nil {
+			return fmt.Errorf("error encoding bulk action: %w", err)
+		}
+
+		// Delete actions do not have a document
+		if doc.Delete {
+			continue
+		}
+
+		if err := encoder.Encode(doc.Document); err != nil {
+			return fmt.Errorf("error encoding document: %w", err)
+		}
+	}
+
+	request := esapi.BulkRequest{
+		Index:   index,
+		Body:    &buf,
+		Refresh: "false",
+	}
+
+	res, err := request.Do(context.Background(), estv.Client)
+	if err != nil {
+		return fmt.Errorf("error bulk indexing documents: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		response, _ := io.ReadAll(res.Body)
+
+		return fmt.Errorf("[%s] Error bulk indexing documents: %s", res.Status(), string(response))
+	}
+
+	response := bulkResponse{}
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return fmt.Errorf("error parsing bulk reply: %w", err)
+	}
+
+	if !response.Errors {
+		return nil
+	}
+
+	var bulkErrors *multierror.Error
+
+	for _, item := range response.Items {
+		for _, result := range item {
+			if result.Error.Reason != "" {
+				bulkErrors = multierror.Append(bulkErrors, fmt.Errorf("[%d] Error indexing document ID=%s: %s",
+					result.Status, result.ID, result.Error.Reason))
+			}
+		}
+	}
+
+	return bulkErrors.ErrorOrNil()
+}
+
 func (estv ElasticTV) RefreshIndices(indices ...string) error {
 	if len(indices) == 0 {
 		return errors.New("no indices to refresh")
@@ -116,6 +209,84 @@ func (estv ElasticTV) RefreshIndices(indices ...string) error {
 	return nil
 }
 
+// deleteByQuery deletes the documents matching the query, returning how many were deleted.
+func (estv ElasticTV) deleteByQuery(query *Query, index string) (int, error) {
+	buf, err := estv.encodeQuery(query)
+	if err != nil {
+		return 0, err
+	}
+
+	refresh := true
+	request := esapi.DeleteByQueryRequest{
+		Index:   []string{index},
+		Body:    buf,
+		Refresh: &refresh,
+	}
+
+	response, err := estv.doByQuery(request)
+
+	return response.Deleted, err
+}
+
+// updateByQuery sets a field of the documents matching the query, returning how many were updated.
+func (estv ElasticTV) updateByQuery(query *Query, index, field string, value any) (int, error) {
+	body := struct {
+		*Query
+		Script struct {
+			Source string         `json:"source"`
+			Params map[string]any `json:"params"`
+		} `json:"script"`
+	}{Query: query}
+	body.Script.Source = "ctx._source[params.field] = params.value"
+	body.Script.Params = map[string]any{"field": field, "value": value}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(body); err != nil {
+		return 0, fmt.Errorf("error encoding query: %w", err)
+	}
+
+	refresh := true
+	request := esapi.UpdateByQueryRequest{
+		Index:     []string{index},
+		Body:      &buf,
+		Refresh:   &refresh,
+		Conflicts: "proceed",
+	}
+
+	response, err := estv.doByQuery(request)
+
+	return response.Updated, err
+}
+
+func (estv ElasticTV) doByQuery(request esapi.Request) (byQueryResponse, error) {
+	response := byQueryResponse{}
+
+	res, err := request.Do(context.Background(), estv.Client)
+	if err != nil {
+		return response, fmt.Errorf("error running query: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		body, _ := io.ReadAll(res.Body)
+
+		return response, fmt.Errorf("[%s] Error running query: %s", res.Status(), string(body))
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return response, fmt.Errorf("error parsing reply: %w", err)
+	}
+
+	var failures *multierror.Error
+
+	for _, failure := range response.Failures {
+		failures = multierror.Append(failures, fmt.Errorf("Error updating document ID=%s: %s",
+			failure.ID, failure.Cause.Reason))
+	}
+
+	return response, failures.ErrorOrNil()
+}
+
 func (estv ElasticTV) GetRecordID(query *Query, index string) (string, error) {
 	id, _, err := estv.queryES(query, index, nil)
 	if err != nil {
@@ -125,6 +296,15 @@ func (estv ElasticTV) GetRecordID(query *Query, index string) (string, error) {
 	return id, nil
 }
 
+func (estv ElasticTV) GetRecord(query *Query, index string, doc interface{}) (string, error) {
+	id, _, err := estv.queryES(query, index, doc)
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
 func (estv ElasticTV) getRecordWithScore(query *Query, index string, doc interface{}) (float64, error) {
 	_, score, err := estv.queryES(query, index, doc)
 	if err != nil {
@@ -135,36 +315,332 @@ func (estv ElasticTV) getRecordWithScore(query *Query, index string, doc interfa
 }
 
 func (estv ElasticTV) UpsertTitle(title Title) error {
-	query := NewQuery().
-		WithTMDbID(title.IDs.TMDb).
-		WithIMDbID(title.IDs.IMDb)
+	if estv.IsBlocked(title) {
+		log.Printf("%s: Skipping blocked title [ %s ]", title.Source, title.Title)
+
+		return nil
+	}
+
+	existing := Title{}
+
+	recordID, err := estv.getTitleRecord(title, &existing)
+	if err != nil {
+		return err
+	}
+
+	title = estv.mergeTitle(title, existing)
+	title.Normalized = title.NormalizedTitles()
+	title.Timestamp = CurrentTimestamp()
+
+	return estv.index(estv.Index.Title, recordID, title)
+}
+
+// BulkUpsertTitles merges titles into the indexed titles with the same IMDb ID and indexes them
+// in a single bulk request. Titles which are not indexed yet are indexed with their IMDb ID as
+// document ID, while blocked titles are skipped.
+func (estv ElasticTV) BulkUpsertTitles(titles []Title) error {
+	allowed := make([]Title, 0, len(titles))
+	imdbIDs := make([]string, 0, len(titles))
 
-	recordID, err := estv.GetRecordID(query, estv.Index.Title)
+	for _, title := range titles {
+		if estv.IsBlocked(title) {
+			log.Printf("%s: Skipping blocked title [ %s ]", title.Source, title.Title)
+
+			continue
+		}
+
+		allowed = append(allowed, title)
+		imdbIDs = append(imdbIDs, title.IDs.IMDb)
+	}
+
+	titles = allowed
+	if len(titles) == 0 {
+		return nil
+	}
+
+	result, err := estv.search(NewQuery().WithIMDbIDs(imdbIDs, nil), estv.Index.Title, maxBulkRecords)
 	if err != nil {
 		return err
 	}
 
-	title.Timestamp = time.Now().UTC().Format(timeFormat)
+	recordIDs := make(map[string]string)
+	existing := make(map[string]Title)
+
+	for _, hit := range result.Hits.Hits {
+		title := Title{}
+		if err := json.Unmarshal(hit.Source, &title); err != nil {
+			return fmt.Errorf("error parsing source: %w", err)
+		}
+
+		recordIDs[title.IDs.IMDb] = hit.ID
+		existing[title.IDs.IMDb] = title
+	}
+
+	docs := make([]BulkDocument, 0, len(titles))
+
+	for _, title := range titles {
+		recordID, ok := recordIDs[title.IDs.IMDb]
+		if ok {
+			// The timestamp of indexed titles is kept so titles are only refreshed when they expire
+			timestamp := existing[title.IDs.IMDb].Timestamp
+			title = estv.mergeTitle(title, existing[title.IDs.IMDb])
+			title.Timestamp = timestamp
+		} else {
+			recordID = title.IDs.IMDb
+		}
+
+		title.Normalized = title.NormalizedTitles()
+		docs = append(docs, BulkDocument{ID: recordID, Document: title})
+	}
+
+	return estv.BulkIndex(estv.Index.Title, docs)
+}
+
+func (estv ElasticTV) getTitleRecord(title Title, doc *Title) (string, error) {
+	queries := make([]*Query, 0)
+	if title.IDs.TMDb > 0 {
+		queries = append(queries, NewQuery().WithTMDbID(title.IDs.TMDb).WithType(title.Type))
+	}
+
+	// Titles from other sources (ex IMDb datasets, TVmaze or Trakt) might not have a TMDb ID
+	if strings.HasPrefix(title.IDs.IMDb, "tt") {
+		queries = append(queries, NewQuery().WithIMDbID(title.IDs.IMDb))
+	}
+
+	if title.IDs.TVmaze > 0 {
+		queries = append(queries, NewQuery().WithTVmazeID(title.IDs.TVmaze).WithType(title.Type))
+	}
+
+	if title.IDs.TVDb > 0 {
+		queries = append(queries, NewQuery().WithTVDbID(title.IDs.TVDb).WithType(title.Type))
+	}
+
+	if title.IDs.Trakt > 0 {
+		queries = append(queries, NewQuery().WithTraktID(title.IDs.Trakt).WithType(title.Type))
+	}
+
+	if title.IDs.Wikidata != "" {
+		queries = append(queries, NewQuery().WithWikidataID(title.IDs.Wikidata).WithType(title.Type))
+	}
+
+	if title.IDs.EIDR != "" {
+		queries = append(queries, NewQuery().WithEIDRID(title.IDs.EIDR).WithType(title.Type))
+	}
+
+	for _, query := range queries {
+		recordID, err := estv.GetRecord(query, estv.Index.Title, doc)
+		if err != nil || recordID != "" {
+			return recordID, err
+		}
+	}
+
+	return "", nil
+}
+
+// AddTitleIDs adds IDs to an indexed title which is found by any of the given IDs. Providers
+// use it to keep IDs a title was looked up with which are not listed in its details.
+func (estv ElasticTV) AddTitleIDs(docType Type, ids IDs) error {
+	if err := estv.RefreshIndices(estv.Index.Title); err != nil {
+		return err
+	}
+
+	title := Title{}
+
+	recordID, err := estv.getTitleRecord(Title{IDs: ids, Type: docType}, &title)
+	if err != nil || recordID == "" {
+		return err
+	}
+
+	if title.IDs == title.IDs.merge(ids) {
+		return nil
+	}
+
+	title.IDs = title.IDs.merge(ids)
 
 	return estv.index(estv.Index.Title, recordID, title)
 }
 
 func (estv ElasticTV) UpsertEpisode(episode Episode) error {
-	query := NewQuery().
-		WithTVShowTMDbID(episode.TVShowIDs.TMDb).
-		WithEpisodeNumber(episode.EpisodeNo).
-		WithSeasonNumber(episode.SeasonNo)
+	// Providers only know their own tv show IDs so complete them from the indexed tv show
+	tvshow := Title{}
+	if _, err := estv.getTitleRecord(Title{IDs: episode.TVShowIDs, Type: TvShowType}, &tvshow); err != nil {
+		return err
+	}
+
+	episode.TVShowIDs = episode.TVShowIDs.merge(tvshow.IDs)
+	existing := Episode{}
 
-	recordID, err := estv.GetRecordID(query, estv.Index.Episode)
+	recordID, err := estv.getEpisodeRecord(episode, &existing)
 	if err != nil {
 		return err
 	}
 
-	episode.Timestamp = time.Now().UTC().Format(timeFormat)
+	episode = estv.mergeEpisode(episode, existing)
+	episode.Timestamp = CurrentTimestamp()
 
 	return estv.index(estv.Index.Episode, recordID, episode)
 }
 
+func (estv ElasticTV) UpsertSeason(season Season) error {
+	if estv.Index.Season == "" {
+		return nil
+	}
+
+	tvshow := Title{}
+	if _, err := estv.getTitleRecord(Title{IDs: season.TVShowIDs, Type: TvShowType}, &tvshow); err != nil {
+		return err
+	}
+
+	season.TVShowIDs = season.TVShowIDs.merge(tvshow.IDs)
+	existing := Season{}
+
+	query := NewQuery().WithTVShowIDs(season.TVShowIDs).WithSeasonNumber(season.SeasonNo)
+
+	recordID, err := estv.GetRecord(query, estv.Index.Season, &existing)
+	if err != nil {
+		return err
+	}
+
+	season = estv.mergeSeason(season, existing)
+	season.Timestamp = CurrentTimestamp()
+
+	return estv.index(estv.Index.Season, recordID, season)
+}
+
+// BulkUpsertSeasonEpisodes merges the episodes of a season of a tv show into the indexed episodes
+// with the same season and episode number, and indexes them in a single bulk request.
+func (estv ElasticTV) BulkUpsertSeasonEpisodes(tvshowIDs IDs, seasonNo uint16, episodes []Episode) error {
+	if len(episodes) == 0 {
+		return nil
+	}
+
+	// Providers only know their own tv show IDs so complete them from the indexed tv show
+	tvshow := Title{}
+	if _, err := estv.getTitleRecord(Title{IDs: tvshowIDs, Type: TvShowType}, &tvshow); err != nil {
+		return err
+	}
+
+	tvshowIDs = tvshowIDs.merge(tvshow.IDs)
+
+	existing, err := estv.getEpisodeRecords(NewQuery().WithTVShowIDs(tvshowIDs).WithSeasonNumber(seasonNo))
+	if err != nil {
+		return err
+	}
+
+	docs := make([]BulkDocument, 0, len(episodes))
+
+	for _, episode := range episodes {
+		episode.TVShowIDs = episode.TVShowIDs.merge(tvshowIDs)
+
+		recordID, current := existing.find(episode.SeasonNo, episode.EpisodeNo)
+
+		episode = estv.mergeEpisode(episode, current)
+		episode.Timestamp = CurrentTimestamp()
+		docs = append(docs, BulkDocument{ID: recordID, Document: episode})
+	}
+
+	return estv.BulkIndex(estv.Index.Episode, docs)
+}
+
+// BulkUpsertEpisodes merges episodes into the indexed episodes with the same IMDb ID, or the
+// same season and episode number of a tv show with the same IMDb ID, and indexes them in a single
+// bulk request. Episodes which are not indexed yet are indexed with their IMDb ID as document ID.
+func (estv ElasticTV) BulkUpsertEpisodes(episodes []Episode) error {
+	if len(episodes) == 0 {
+		return nil
+	}
+
+	imdbIDs := make([]string, 0, len(episodes))
+	tvshowIMDbIDs := make([]string, 0)
+
+	for _, episode := range episodes {
+		imdbIDs = append(imdbIDs, episode.IDs.IMDb)
+		if episode.TVShowIDs.IMDb != "" && !containsString(tvshowIMDbIDs, episode.TVShowIDs.IMDb) {
+			tvshowIMDbIDs = append(tvshowIMDbIDs, episode.TVShowIDs.IMDb)
+		}
+	}
+
+	query := NewQuery().WithIMDbIDs(imdbIDs, tvshowIMDbIDs)
+
+	result, err := estv.search(query, estv.Index.Episode, maxBulkRecords)
+	if err != nil {
+		return err
+	}
+
+	recordIDs := make(map[string]string)
+	existing := make(map[string]Episode)
+
+	for _, hit := range result.Hits.Hits {
+		episode := Episode{}
+		if err := json.Unmarshal(hit.Source, &episode); err != nil {
+			return fmt.Errorf("error parsing source: %w", err)
+		}
+
+		// Episodes indexed by providers might not have an IMDb ID
+		for _, key := range []string{episode.IDs.IMDb, episodeKey(episode)} {
+			if _, ok := recordIDs[key]; key != "" && !ok {
+				recordIDs[key] = hit.ID
+				existing[key] = episode
+			}
+		}
+	}
+
+	docs := make([]BulkDocument, 0, len(episodes))
+
+	for _, episode := range episodes {
+		key := episode.IDs.IMDb
+		if _, ok := recordIDs[key]; !ok {
+			key = episodeKey(episode)
+		}
+
+		recordID, ok := recordIDs[key]
+		if ok {
+			timestamp := existing[key].Timestamp
+			episode = estv.mergeEpisode(episode, existing[key])
+			episode.Timestamp = timestamp
+		} else {
+			recordID = episode.IDs.IMDb
+		}
+
+		docs = append(docs, BulkDocument{ID: recordID, Document: episode})
+	}
+
+	return estv.BulkIndex(estv.Index.Episode, docs)
+}
+
+// episodeKey identifies an episode by the IMDb ID of its tv show and its season and episode number.
+func episodeKey(episode Episode) string {
+	if episode.TVShowIDs.IMDb == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("%s/%d/%d", episode.TVShowIDs.IMDb, episode.SeasonNo, episode.EpisodeNo)
+}
+
+func (estv ElasticTV) getEpisodeRecord(episode Episode, doc *Episode) (string, error) {
+	if episode.TVShowIDs != (IDs{}) {
+		query := NewQuery().
+			WithTVShowIDs(episode.TVShowIDs).
+			WithEpisodeNumber(episode.EpisodeNo).
+			WithSeasonNumber(episode.SeasonNo)
+
+		recordID, err := estv.GetRecord(query, estv.Index.Episode, doc)
+		if err != nil || recordID != "" {
+			return recordID, err
+		}
+	}
+
+	if strings.HasPrefix(episode.IDs.IMDb, "tt") {
+		return estv.GetRecord(NewQuery().WithIMDbID(episode.IDs.IMDb), estv.Index.Episode, doc)
+	}
+
+	return "", nil
+}
+
+func CurrentTimestamp() string {
+	return time.Now().UTC().Format(timeFormat)
+}
+
 func (estv ElasticTV) IsRecordExpired(query *Query, index string) bool {
 	var docTimestamp Timestamp
 
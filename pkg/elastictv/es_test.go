@@ -0,0 +1,66 @@
+package elastictv
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8"
+)
+
+// esRequest is a request received by the test elasticsearch server.
+type esRequest struct {
+	Method string
+	Path   string
+	Body   string
+}
+
+// newTestElasticTV returns an ElasticTV backed by a test elasticsearch server, which replies to
+// each request with the body returned by reply and records the requests it receives.
+func newTestElasticTV(t *testing.T, reply func(request esRequest) string) (*ElasticTV, *[]esRequest) {
+	t.Helper()
+
+	requests := make([]esRequest, 0)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Error(err)
+		}
+
+		request := esRequest{Method: r.Method, Path: r.URL.Path, Body: string(body)}
+		requests = append(requests, request)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Write([]byte(reply(request)))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := elasticsearch.NewClient(elasticsearch.Config{Addresses: []string{server.URL}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &ElasticTV{
+		Client:          client,
+		Providers:       make([]SearchableProvider, 0),
+		providerOptions: make(map[string]providerOptions),
+		blocklist:       &cache[BlocklistEntry]{},
+		overrides:       &cache[Override]{},
+		UpdateAfter:     time.Now().AddDate(0, 0, -defaultUpdateAfterDays),
+		updateAfterDays: defaultUpdateAfterDays,
+		Index: index{
+			Title:     "titles",
+			Episode:   "episodes",
+			Season:    "seasons",
+			Search:    "search",
+			Override:  "overrides",
+			Blocklist: "blocklist",
+		},
+	}, &requests
+}
+
+const noHits = `{"hits":{"total":{"value":0},"hits":[]}}`
@@ -0,0 +1,443 @@
+package imdb
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/viper"
+
+	"github.com/shaunschembri/elastictv/pkg/elastictv"
+)
+
+const (
+	titleBasicsFile     = "title.basics.tsv.gz"
+	titleAkasFile       = "title.akas.tsv.gz"
+	titleEpisodeFile    = "title.episode.tsv.gz"
+	titleRatingsFile    = "title.ratings.tsv.gz"
+	titlePrincipalsFile = "title.principals.tsv.gz"
+	nameBasicsFile      = "name.basics.tsv.gz"
+
+	defaultBatchSize = 1000
+	maxActors        = 10
+	maxOtherCredits  = 5
+	episodeTitleType = "tvEpisode"
+)
+
+var titleTypes = map[string]elastictv.Type{
+	"movie":        elastictv.MovieType,
+	"tvMovie":      elastictv.MovieType,
+	"tvSeries":     elastictv.TvShowType,
+	"tvMiniSeries": elastictv.TvShowType,
+}
+
+// sink indexes the imported titles and episodes, which is ElasticTV outside of tests.
+type sink interface {
+	IsBlocked(title elastictv.Title) bool
+	BulkUpsertTitles(titles []elastictv.Title) error
+	BulkUpsertEpisodes(episodes []elastictv.Episode) error
+	RefreshIndices(indices ...string) error
+}
+
+type Importer struct {
+	sink sink
+	// Indices refreshed once the import completes
+	indices []string
+	// Directory containing the gzipped IMDb datasets
+	dir       string
+	batchSize int
+	// File used to store the ID of the last imported title so that an interrupted import can be resumed
+	checkpointFile string
+	// List of region codes to use titles from, or all regions if empty
+	aliasRegions []string
+}
+
+type importBatch struct {
+	titles   []elastictv.Title
+	episodes []elastictv.Episode
+}
+
+func (b importBatch) len() int {
+	return len(b.titles) + len(b.episodes)
+}
+
+type datasets struct {
+	basics     *tsvReader
+	akas       *tsvReader
+	ratings    *tsvReader
+	principals *tsvReader
+	episodes   *tsvReader
+}
+
+func (d datasets) Close() {
+	for _, r := range []*tsvReader{d.basics, d.akas, d.ratings, d.principals, d.episodes} {
+		if r != nil {
+			r.Close()
+		}
+	}
+}
+
+type titleRows struct {
+	basics     []string
+	akas       [][]string
+	ratings    [][]string
+	principals [][]string
+	episode    [][]string
+}
+
+func NewImporter(estv *elastictv.ElasticTV) Importer {
+	batchSize := viper.GetInt("elastictv.importer.imdb.batch_size")
+	if batchSize == 0 {
+		batchSize = defaultBatchSize
+	}
+
+	return Importer{
+		sink:           estv,
+		indices:        []string{estv.Index.Title, estv.Index.Episode},
+		dir:            viper.GetString("elastictv.importer.imdb.path"),
+		batchSize:      batchSize,
+		checkpointFile: viper.GetString("elastictv.importer.imdb.checkpoint_file"),
+		aliasRegions:   viper.GetStringSlice("elastictv.importer.imdb.alias_regions"),
+	}
+}
+
+func (i Importer) Name() string {
+	return "IMDb"
+}
+
+// Import streams the IMDb datasets and bulk loads movies, tv shows and episodes into the
+// title and episode indices. If a checkpoint file is configured, titles up to the last
+// checkpoint are skipped.
+func (i Importer) Import() error {
+	checkpoint, err := i.readCheckpoint()
+	if err != nil {
+		return err
+	}
+
+	names, err := i.loadNames()
+	if err != nil {
+		return err
+	}
+
+	d, err := i.openDatasets()
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+
+	if checkpoint != "" {
+		log.Printf("%s: Resuming import after title [ %s ]", i.Name(), checkpoint)
+	}
+
+	batch := importBatch{}
+	imported := 0
+	lastID := ""
+
+	for {
+		row, err := d.basics.next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return err
+		}
+
+		rows := titleRows{basics: row}
+		id := d.basics.value(row, "tconst")
+
+		if err := d.readMatching(id, &rows); err != nil {
+			return err
+		}
+
+		if checkpoint != "" && compareIDs(id, checkpoint) <= 0 {
+			continue
+		}
+
+		lastID = id
+		titleType := d.basics.value(row, "titleType")
+
+		if titleType == episodeTitleType {
+			if episode, ok := i.getEpisode(d, rows); ok {
+				batch.episodes = append(batch.episodes, episode)
+			}
+		} else if docType, ok := titleTypes[titleType]; ok {
+			if title := i.getTitle(d, docType, rows, names); !i.sink.IsBlocked(title) {
+				batch.titles = append(batch.titles, title)
+			}
+		}
+
+		if batch.len() >= i.batchSize {
+			imported += batch.len()
+			if err := i.flush(batch, lastID); err != nil {
+				return err
+			}
+
+			log.Printf("%s: Imported %d titles and episodes [ last ID: %s ]", i.Name(), imported, lastID)
+
+			batch = importBatch{}
+		}
+	}
+
+	imported += batch.len()
+	if err := i.flush(batch, lastID); err != nil {
+		return err
+	}
+
+	log.Printf("%s: Import completed with %d titles and episodes", i.Name(), imported)
+
+	return i.sink.RefreshIndices(i.indices...)
+}
+
+func (i Importer) openDatasets() (datasets, error) {
+	d := datasets{}
+	files := map[string]**tsvReader{
+		titleBasicsFile:     &d.basics,
+		titleAkasFile:       &d.akas,
+		titleRatingsFile:    &d.ratings,
+		titlePrincipalsFile: &d.principals,
+		titleEpisodeFile:    &d.episodes,
+	}
+
+	for name, reader := range files {
+		r, err := openTSV(i.dir, name)
+		if err != nil {
+			d.Close()
+
+			return datasets{}, err
+		}
+
+		*reader = r
+	}
+
+	return d, nil
+}
+
+// readMatching advances the datasets keyed by title ID to the given ID and collects their rows.
+func (d datasets) readMatching(id string, rows *titleRows) error {
+	var err error
+
+	if rows.akas, err = d.akas.readMatching(id); err != nil {
+		return err
+	}
+
+	if rows.ratings, err = d.ratings.readMatching(id); err != nil {
+		return err
+	}
+
+	if rows.principals, err = d.principals.readMatching(id); err != nil {
+		return err
+	}
+
+	rows.episode, err = d.episodes.readMatching(id)
+
+	return err
+}
+
+// flush merges the batch into the titles and episodes already indexed, such as those written by
+// providers or by a previous import, so they are not duplicated.
+func (i Importer) flush(batch importBatch, lastID string) error {
+	if err := i.sink.BulkUpsertTitles(batch.titles); err != nil {
+		return fmt.Errorf("%s: error indexing titles: %w", i.Name(), err)
+	}
+
+	if err := i.sink.BulkUpsertEpisodes(batch.episodes); err != nil {
+		return fmt.Errorf("%s: error indexing episodes: %w", i.Name(), err)
+	}
+
+	return i.writeCheckpoint(lastID)
+}
+
+// getTitle builds a title from the rows of the datasets. Titles are written with an expired
+// timestamp since they lack the details of the providers, which are fetched when they are
+// first looked up.
+func (i Importer) getTitle(d datasets, docType elastictv.Type, rows titleRows, names map[string]string) elastictv.Title {
+	name := d.basics.value(rows.basics, "primaryTitle")
+
+	title := elastictv.Title{
+		Title:     name,
+		Type:      docType,
+		Year:      i.getNumber(d.basics.value(rows.basics, "startYear")),
+		Genre:     i.getGenres(d.basics.value(rows.basics, "genres")),
+		IDs:       elastictv.IDs{IMDb: d.basics.value(rows.basics, "tconst")},
+		Rating:    i.getRating(d.ratings, rows.ratings),
+		Alias:     i.getAliases(d.akas, rows.akas, name, d.basics.value(rows.basics, "originalTitle")),
+		Credits:   i.getCredits(d.principals, rows.principals, names),
+		Source:    i.Name(),
+		Timestamp: elastictv.ExpiredTimestamp,
+	}
+	title.Normalized = title.NormalizedTitles()
+
+	return title
+}
+
+func (i Importer) getEpisode(d datasets, rows titleRows) (elastictv.Episode, bool) {
+	if len(rows.episode) == 0 {
+		return elastictv.Episode{}, false
+	}
+
+	episode := rows.episode[0]
+
+	return elastictv.Episode{
+		Title:     d.basics.value(rows.basics, "primaryTitle"),
+		IDs:       elastictv.IDs{IMDb: d.basics.value(rows.basics, "tconst")},
+		TVShowIDs: elastictv.IDs{IMDb: d.episodes.value(episode, "parentTconst")},
+		SeasonNo:  i.getNumber(d.episodes.value(episode, "seasonNumber")),
+		EpisodeNo: i.getNumber(d.episodes.value(episode, "episodeNumber")),
+		Rating:    i.getRating(d.ratings, rows.ratings),
+		Source:    i.Name(),
+		Timestamp: elastictv.ExpiredTimestamp,
+	}, true
+}
+
+func (i Importer) getNumber(value string) uint16 {
+	number, _ := strconv.ParseUint(value, 10, 16)
+
+	return uint16(number)
+}
+
+func (i Importer) getGenres(genres string) []string {
+	if genres == "" {
+		return nil
+	}
+
+	return strings.Split(genres, ",")
+}
+
+func (i Importer) getRating(ratings *tsvReader, rows [][]string) elastictv.Ratings {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	rating, err := strconv.ParseFloat(ratings.value(rows[0], "averageRating"), 32)
+	if err != nil || rating == 0 {
+		return nil
+	}
+
+	return elastictv.Ratings{{
+		Value:  float32(rating),
+		Source: i.Name(),
+	}}
+}
+
+// getAliases returns the original title and the titles of the regions in aliasRegions, or of
+// all regions when no regions are configured.
+func (i Importer) getAliases(akas *tsvReader, rows [][]string, title, originalTitle string) []string {
+	aliases := make([]string, 0)
+	if originalTitle != "" && !strings.EqualFold(originalTitle, title) {
+		aliases = append(aliases, originalTitle)
+	}
+
+	for _, row := range rows {
+		alias := akas.value(row, "title")
+		if alias == "" || strings.EqualFold(alias, title) || i.contains(aliases, alias) {
+			continue
+		}
+
+		if len(i.aliasRegions) > 0 && !i.contains(i.aliasRegions, akas.value(row, "region")) {
+			continue
+		}
+
+		aliases = append(aliases, alias)
+	}
+
+	return aliases
+}
+
+func (i Importer) getCredits(principals *tsvReader, rows [][]string, names map[string]string) elastictv.Credits {
+	credits := elastictv.Credits{}
+
+	for _, row := range rows {
+		name, ok := names[principals.value(row, "nconst")]
+		if !ok {
+			continue
+		}
+
+		switch principals.value(row, "category") {
+		case "director":
+			credits.Director = append(credits.Director, name)
+		case "actor", "actress", "self":
+			if len(credits.Actor) < maxActors {
+				credits.Actor = append(credits.Actor, name)
+			}
+		case "producer", "writer":
+			if len(credits.Other) < maxOtherCredits {
+				credits.Other = append(credits.Other, name)
+			}
+		}
+	}
+
+	return credits
+}
+
+// loadNames loads the name of every person listed in the name.basics dataset keyed by IMDb
+// person ID, which is used to resolve the credits in the title.principals dataset.
+func (i Importer) loadNames() (map[string]string, error) {
+	r, err := openTSV(i.dir, nameBasicsFile)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	log.Printf("%s: Loading names from %s", i.Name(), nameBasicsFile)
+
+	names := make(map[string]string)
+
+	for {
+		row, err := r.next()
+		if errors.Is(err, io.EOF) {
+			return names, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if name := r.value(row, "primaryName"); name != "" {
+			names[r.value(row, "nconst")] = name
+		}
+	}
+}
+
+func (i Importer) readCheckpoint() (string, error) {
+	if i.checkpointFile == "" {
+		return "", nil
+	}
+
+	checkpoint, err := os.ReadFile(i.checkpointFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("%s: error reading checkpoint file: %w", i.Name(), err)
+	}
+
+	return strings.TrimSpace(string(checkpoint)), nil
+}
+
+func (i Importer) writeCheckpoint(lastID string) error {
+	if i.checkpointFile == "" || lastID == "" {
+		return nil
+	}
+
+	if err := os.WriteFile(i.checkpointFile, []byte(lastID+"\n"), 0o600); err != nil {
+		return fmt.Errorf("%s: error writing checkpoint file: %w", i.Name(), err)
+	}
+
+	return nil
+}
+
+func (i Importer) contains(stringSlice []string, value string) bool {
+	for _, n := range stringSlice {
+		if strings.EqualFold(n, value) {
+			return true
+		}
+	}
+
+	return false
+}
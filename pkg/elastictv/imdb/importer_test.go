@@ -0,0 +1,190 @@
+package imdb
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/shaunschembri/elastictv/pkg/elastictv"
+)
+
+// stubSink records the batches of an import instead of indexing them, and blocks the titles
+// with the IMDb IDs in blocked.
+type stubSink struct {
+	blocked   []string
+	titles    []elastictv.Title
+	episodes  []elastictv.Episode
+	refreshed []string
+}
+
+func (s *stubSink) IsBlocked(title elastictv.Title) bool {
+	for _, id := range s.blocked {
+		if title.IDs.IMDb == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *stubSink) BulkUpsertTitles(titles []elastictv.Title) error {
+	s.titles = append(s.titles, titles...)
+
+	return nil
+}
+
+func (s *stubSink) BulkUpsertEpisodes(episodes []elastictv.Episode) error {
+	s.episodes = append(s.episodes, episodes...)
+
+	return nil
+}
+
+func (s *stubSink) RefreshIndices(indices ...string) error {
+	s.refreshed = append(s.refreshed, indices...)
+
+	return nil
+}
+
+// importFixtures imports the datasets in testdata into a stub sink.
+func importFixtures(t *testing.T, i Importer, blocked ...string) *stubSink {
+	t.Helper()
+
+	sink := &stubSink{blocked: blocked}
+	i.sink = sink
+	i.dir = "testdata"
+	i.indices = []string{"titles", "episodes"}
+
+	if i.batchSize == 0 {
+		i.batchSize = defaultBatchSize
+	}
+
+	if err := i.Import(); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(sink.refreshed, i.indices) {
+		t.Errorf("refreshed %v, want %v", sink.refreshed, i.indices)
+	}
+
+	return sink
+}
+
+func TestImporterTitles(t *testing.T) {
+	titles := importFixtures(t, Importer{aliasRegions: []string{"FR", "IT"}}).titles
+
+	want := []elastictv.Title{
+		{
+			Title:   "The Matrix",
+			Type:    elastictv.MovieType,
+			Year:    1999,
+			Genre:   []string{"Action", "Sci-Fi"},
+			IDs:     elastictv.IDs{IMDb: "tt0133093"},
+			Rating:  elastictv.Ratings{{Source: "IMDb", Value: 8.7}},
+			Alias:   []string{"Matrix"},
+			Credits: elastictv.Credits{Actor: []string{"Keanu Reeves", "Carrie-Anne Moss"}, Director: []string{"Lana Wachowski"}},
+		},
+		{
+			Title:   "Breaking Bad",
+			Type:    elastictv.TvShowType,
+			Year:    2008,
+			Genre:   []string{"Crime", "Drama", "Thriller"},
+			IDs:     elastictv.IDs{IMDb: "tt0903747"},
+			Alias:   []string{},
+			Credits: elastictv.Credits{Actor: []string{"Bryan Cranston"}},
+		},
+	}
+
+	if len(titles) != len(want) {
+		t.Fatalf("got %d titles, want %d", len(titles), len(want))
+	}
+
+	for n, title := range titles {
+		if title.Source != "IMDb" || title.Timestamp != elastictv.ExpiredTimestamp {
+			t.Errorf("%s: source %q and timestamp %q, want IMDb with an expired timestamp",
+				title.Title, title.Source, title.Timestamp)
+		}
+
+		if len(title.Normalized) == 0 {
+			t.Errorf("%s: normalized titles are not set", title.Title)
+		}
+
+		title.Source, title.Timestamp, title.Normalized = "", "", nil
+		if !reflect.DeepEqual(title, want[n]) {
+			t.Errorf("title = %+v, want %+v", title, want[n])
+		}
+	}
+}
+
+func TestImporterEpisodes(t *testing.T) {
+	episodes := importFixtures(t, Importer{batchSize: 1}).episodes
+
+	want := []elastictv.Episode{{
+		Title:     "Pilot",
+		IDs:       elastictv.IDs{IMDb: "tt0959621"},
+		TVShowIDs: elastictv.IDs{IMDb: "tt0903747"},
+		SeasonNo:  1,
+		EpisodeNo: 1,
+		Rating:    elastictv.Ratings{{Source: "IMDb", Value: 9}},
+		Source:    "IMDb",
+		Timestamp: elastictv.ExpiredTimestamp,
+	}}
+
+	if !reflect.DeepEqual(episodes, want) {
+		t.Errorf("episodes = %+v, want %+v", episodes, want)
+	}
+}
+
+func TestImporterCheckpoint(t *testing.T) {
+	i := Importer{checkpointFile: t.TempDir() + "/checkpoint"}
+
+	checkpoint, err := i.readCheckpoint()
+	if err != nil || checkpoint != "" {
+		t.Fatalf("readCheckpoint() = %q, %v, want no checkpoint", checkpoint, err)
+	}
+
+	if err := i.writeCheckpoint("tt0903747"); err != nil {
+		t.Fatal(err)
+	}
+
+	if checkpoint, err = i.readCheckpoint(); err != nil || checkpoint != "tt0903747" {
+		t.Errorf("readCheckpoint() = %q, %v, want tt0903747", checkpoint, err)
+	}
+}
+
+func TestImporterAllAliasRegions(t *testing.T) {
+	titles := importFixtures(t, Importer{}).titles
+	if len(titles) == 0 {
+		t.Fatal("no titles imported")
+	}
+
+	if want := []string{"Matrix", "Матрица"}; !reflect.DeepEqual(titles[0].Alias, want) {
+		t.Errorf("aliases = %v, want %v", titles[0].Alias, want)
+	}
+}
+
+func TestImporterBlocked(t *testing.T) {
+	titles := importFixtures(t, Importer{}, "tt0133093").titles
+
+	if len(titles) != 1 || titles[0].IDs.IMDb != "tt0903747" {
+		t.Errorf("titles = %+v, want only Breaking Bad", titles)
+	}
+}
+
+func TestImporterResume(t *testing.T) {
+	checkpointFile := t.TempDir() + "/checkpoint"
+	if err := os.WriteFile(checkpointFile, []byte("tt0903747\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	sink := importFixtures(t, Importer{checkpointFile: checkpointFile})
+
+	if len(sink.titles) != 0 || len(sink.episodes) != 1 || sink.episodes[0].IDs.IMDb != "tt0959621" {
+		t.Errorf("resumed import indexed titles %+v and episodes %+v, want only the Pilot episode",
+			sink.titles, sink.episodes)
+	}
+
+	checkpoint, err := os.ReadFile(checkpointFile)
+	if err != nil || string(checkpoint) != "tt10000000\n" {
+		t.Errorf("checkpoint = %q, %v, want the last title of the datasets", checkpoint, err)
+	}
+}
@@ -0,0 +1,161 @@
+package imdb
+
+import (
+	"bufio"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const nullValue = `\N`
+
+type tsvReader struct {
+	name    string
+	file    *os.File
+	gzip    *gzip.Reader
+	reader  *bufio.Reader
+	columns map[string]int
+	peeked  []string
+}
+
+func openTSV(dir, name string) (*tsvReader, error) {
+	file, err := os.Open(filepath.Join(dir, name))
+	if err != nil {
+		return nil, fmt.Errorf("error opening dataset %s: %w", name, err)
+	}
+
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		file.Close()
+
+		return nil, fmt.Errorf("error decompressing dataset %s: %w", name, err)
+	}
+
+	r := &tsvReader{
+		name:    name,
+		file:    file,
+		gzip:    gzipReader,
+		reader:  bufio.NewReaderSize(gzipReader, 1<<20),
+		columns: make(map[string]int),
+	}
+
+	header, err := r.readLine()
+	if err != nil {
+		r.Close()
+
+		return nil, fmt.Errorf("error reading header of dataset %s: %w", name, err)
+	}
+
+	for i, column := range header {
+		r.columns[column] = i
+	}
+
+	return r, nil
+}
+
+func (r *tsvReader) readLine() ([]string, error) {
+	line, err := r.reader.ReadString('\n')
+	if err != nil && !(errors.Is(err, io.EOF) && line != "") {
+		return nil, err
+	}
+
+	return strings.Split(strings.TrimRight(line, "\r\n"), "\t"), nil
+}
+
+// next returns the next row of the dataset or io.EOF when there are no more rows.
+func (r *tsvReader) next() ([]string, error) {
+	if r.peeked != nil {
+		row := r.peeked
+		r.peeked = nil
+
+		return row, nil
+	}
+
+	row, err := r.readLine()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, err
+		}
+
+		return nil, fmt.Errorf("error reading dataset %s: %w", r.name, err)
+	}
+
+	return row, nil
+}
+
+func (r *tsvReader) peek() ([]string, error) {
+	if r.peeked != nil {
+		return r.peeked, nil
+	}
+
+	row, err := r.next()
+	if err != nil {
+		return nil, err
+	}
+
+	r.peeked = row
+
+	return row, nil
+}
+
+// readMatching returns all rows which have the given ID in the first column. All datasets
+// are sorted by title ID, so rows with a lower ID are skipped.
+func (r *tsvReader) readMatching(id string) ([][]string, error) {
+	rows := make([][]string, 0)
+
+	for {
+		row, err := r.peek()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		cmp := compareIDs(row[0], id)
+		if cmp > 0 {
+			return rows, nil
+		}
+
+		r.peeked = nil
+
+		if cmp == 0 {
+			rows = append(rows, row)
+		}
+	}
+}
+
+// value returns the value of the named column or an empty string if the value is null.
+func (r *tsvReader) value(row []string, column string) string {
+	i, ok := r.columns[column]
+	if !ok || i >= len(row) || row[i] == nullValue {
+		return ""
+	}
+
+	return row[i]
+}
+
+func (r *tsvReader) Close() error {
+	r.gzip.Close()
+
+	return r.file.Close()
+}
+
+// compareIDs compares IMDb IDs (ex tt0000001 and tt10000000) numerically as the datasets
+// are not sorted lexicographically.
+func compareIDs(a, b string) int {
+	if len(a) != len(b) {
+		if len(a) < len(b) {
+			return -1
+		}
+
+		return 1
+	}
+
+	return strings.Compare(a, b)
+}
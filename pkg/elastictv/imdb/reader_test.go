@@ -0,0 +1,105 @@
+package imdb
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestCompareIDs(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"tt0000001", "tt0000001", 0},
+		{"tt0000001", "tt0000002", -1},
+		{"tt9999999", "tt10000000", -1},
+		{"tt10000000", "tt9999999", 1},
+	}
+
+	for _, tt := range tests {
+		if got := compareIDs(tt.a, tt.b); got != tt.want {
+			t.Errorf("compareIDs(%s, %s) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestTSVReader(t *testing.T) {
+	r, err := openTSV("testdata", titleBasicsFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	ids := make([]string, 0)
+
+	for {
+		row, err := r.next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ids = append(ids, r.value(row, "tconst"))
+
+		if r.value(row, "tconst") == "tt0133093" {
+			if got := r.value(row, "endYear"); got != "" {
+				t.Errorf("null value = %q, want empty", got)
+			}
+
+			if got := r.value(row, "unknown"); got != "" {
+				t.Errorf("unknown column = %q, want empty", got)
+			}
+		}
+	}
+
+	want := []string{"tt0133093", "tt0903747", "tt0959621", "tt10000000"}
+	if !reflect.DeepEqual(ids, want) {
+		t.Errorf("ids = %v, want %v", ids, want)
+	}
+}
+
+func TestTSVReaderReadMatching(t *testing.T) {
+	r, err := openTSV("testdata", titleAkasFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	tests := []struct {
+		id   string
+		want []string
+	}{
+		// Rows of titles which are not read (ex tt0000001) are skipped
+		{"tt0133093", []string{"The Matrix", "Matrix", "Matrix", "Матрица"}},
+		{"tt0800000", []string{}},
+		{"tt0903747", []string{"Breaking Bad"}},
+		{"tt10000000", []string{}},
+	}
+
+	for _, tt := range tests {
+		rows, err := r.readMatching(tt.id)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		titles := make([]string, 0, len(rows))
+		for _, row := range rows {
+			titles = append(titles, r.value(row, "title"))
+		}
+
+		if !reflect.DeepEqual(titles, tt.want) {
+			t.Errorf("readMatching(%s) = %v, want %v", tt.id, titles, tt.want)
+		}
+	}
+}
+
+func TestOpenTSVMissingFile(t *testing.T) {
+	if _, err := openTSV("testdata", "missing.tsv.gz"); err == nil {
+		t.Error("expected an error opening a missing dataset")
+	}
+}
@@ -7,6 +7,11 @@ import (
 	"github.com/spf13/viper"
 )
 
+const (
+	defaultFuzzyBoost     = 0.5
+	defaultMovieYearRange = 1
+)
+
 type LookupCommonParams struct {
 	Title    []string
 	Director []string
@@ -15,6 +20,50 @@ type LookupCommonParams struct {
 	Country  []string
 	Genre    []string
 	IMDbID   string
+	// Filename is matched against the filename patterns of overrides
+	Filename string
+	// Fuzzy overrides the elastictv.title.fuzzy configuration of the matching of titles with typos
+	Fuzzy FuzzyMatchParams
+}
+
+// FuzzyMatchParams tunes the tier which matches titles with typos (ex Interstelar), which is
+// only queried when no title matches exactly with the minimum score of the lookup. The tier is
+// disabled unless Fuzziness or NGram is set.
+type FuzzyMatchParams struct {
+	// Fuzziness is the maximum edit distance of a word (ex AUTO, 1 or 2)
+	Fuzziness string
+	// NGram matches titles which share trigrams, using the ngram fields of configs/title.json.
+	// Nil keeps the elastictv.title.fuzzy.ngram configuration.
+	NGram *bool
+	// Boost of the fuzzy matches relative to exact matches
+	Boost float32
+}
+
+func (c LookupCommonParams) getFuzzyMatchParams() FuzzyMatchParams {
+	ngram := viper.GetBool("elastictv.title.fuzzy.ngram")
+	params := FuzzyMatchParams{
+		Fuzziness: viper.GetString("elastictv.title.fuzzy.fuzziness"),
+		NGram:     &ngram,
+		Boost:     defaultFuzzyBoost,
+	}
+
+	if viper.IsSet("elastictv.title.fuzzy.boost") {
+		params.Boost = float32(viper.GetFloat64("elastictv.title.fuzzy.boost"))
+	}
+
+	if c.Fuzzy.Fuzziness != "" {
+		params.Fuzziness = c.Fuzzy.Fuzziness
+	}
+
+	if c.Fuzzy.NGram != nil {
+		params.NGram = c.Fuzzy.NGram
+	}
+
+	if c.Fuzzy.Boost > 0 {
+		params.Boost = c.Fuzzy.Boost
+	}
+
+	return params
 }
 
 func (c LookupCommonParams) hasCredits() bool {
@@ -24,6 +73,7 @@ func (c LookupCommonParams) hasCredits() bool {
 func (c LookupCommonParams) getCommonTitleQuery() *Query {
 	return NewQuery().
 		WithTitles(c.Title...).
+		WithFuzzyTitles(c.getFuzzyMatchParams(), c.Title...).
 		WithGenres(c.Genre...).
 		WithDirectors(c.Director...).
 		WithActors(c.Actor...).
@@ -50,7 +100,7 @@ func (c LookupCommonParams) getSearchItemsFromDetails(docType Type, year uint16)
 
 func (estv ElasticTV) lookupTitle(query *Query, searchItems SearchItems, minScoreNoSearch, minScore float64) (*Title, float64, error) {
 	title := &Title{}
-	score, err := estv.getRecordWithScore(query, estv.Index.Title, title)
+	score, err := estv.matchTitle(query, minScoreNoSearch, title)
 	if err != nil {
 		return nil, 0, fmt.Errorf("error looking for title: %w", err)
 	}
@@ -59,14 +109,28 @@ func (estv ElasticTV) lookupTitle(query *Query, searchItems SearchItems, minScor
 		return title, score, nil
 	}
 
-	errors := estv.searchTitles(searchItems)
-	score, err = estv.getRecordWithScore(query, estv.Index.Title, title)
+	errors := estv.searchTitles(searchItems, func() bool {
+		if err := estv.RefreshIndices(estv.Index.Title); err != nil {
+			return false
+		}
+
+		score, err := estv.matchTitle(query, minScore, nil)
+
+		return err == nil && score > 0 && score >= minScore
+	})
+	score, err = estv.matchTitle(query, minScore, title)
 	if err != nil {
 		errors = multierror.Append(errors, fmt.Errorf("error looking for title: %w", err))
 
 		return nil, 0, errors
 	}
 
+	if score == 0 {
+		errors = multierror.Append(errors, fmt.Errorf("title not found"))
+
+		return nil, 0, errors
+	}
+
 	if score < minScore {
 		errors = multierror.Append(errors,
 			fmt.Errorf("found title [%s] has too low score %3.1f (min %3.1f)", title.Title, score, minScore))
@@ -77,32 +141,49 @@ func (estv ElasticTV) lookupTitle(query *Query, searchItems SearchItems, minScor
 	return title, score, errors.ErrorOrNil()
 }
 
-func (estv ElasticTV) searchTitles(searchTitles SearchItems) *multierror.Error {
+// matchTitle returns the score of the best unblocked title matching the query. Titles are only
+// matched with typos when no title matches exactly with a score above minScore.
+func (estv ElasticTV) matchTitle(query *Query, minScore float64, title *Title) (float64, error) {
+	score, err := estv.getUnblockedTitle(query, title)
+	if err != nil || (score > 0 && score > minScore) {
+		return score, err
+	}
+
+	fuzzy := query.fuzzyQuery()
+	if fuzzy == nil {
+		return score, nil
+	}
+
+	return estv.getUnblockedTitle(fuzzy, title)
+}
+
+// searchTitles queries the providers for the search items which are not cached. isFound
+// reports whether the title being looked up was found, to skip any fallback providers.
+func (estv ElasticTV) searchTitles(searchTitles SearchItems, isFound func() bool) *multierror.Error {
 	var errors *multierror.Error
 
+	items := make(SearchItems, 0)
 	for _, item := range searchTitles {
-		query := NewQuery().WithSearchItem(item)
-		if !estv.IsRecordExpired(query, estv.Index.Search) {
-			continue
+		if estv.IsRecordExpired(NewQuery().WithSearchItem(item), estv.Index.Search) {
+			items = append(items, item)
 		}
+	}
 
-		for _, provider := range estv.Providers {
-			var err error
-
-			switch item.Type {
-			case MovieType:
-				err = provider.SearchMovies(item)
-			case TvShowType:
-				err = provider.SearchTvShows(item)
-			default:
-				return nil
-			}
-
-			if err != nil {
-				errors = multierror.Append(errors, err)
-			}
+	searchErrors, served := estv.searchProviders(items, isFound, func(provider SearchableProvider, item SearchItem) error {
+		switch item.Type {
+		case MovieType:
+			return provider.SearchMovies(item)
+		case TvShowType:
+			return provider.SearchTvShows(item)
+		default:
+			return nil
 		}
+	})
+	errors = multierror.Append(errors, searchErrors...)
 
+	// Search items which no provider supports are not recorded so they are searched once a
+	// provider supports them
+	for _, item := range served {
 		if err := estv.indexSearchItem(item); err != nil {
 			errors = multierror.Append(errors, err)
 		}
@@ -115,14 +196,38 @@ func (estv ElasticTV) searchTitles(searchTitles SearchItems) *multierror.Error {
 	return errors
 }
 
+// LookupTitleByID looks up a movie or tv show by the ID of the given search attribute (ex
+// TVDbIDSearchAttribute), querying any provider able to resolve that ID type when the title
+// is not cached. Titles looked up by an ID which no provider resolves are only found if cached.
+func (estv ElasticTV) LookupTitleByID(docType Type, attribute SearchAttribute, id any) (*Title, float64, error) {
+	query, err := NewQuery().WithExternalID(attribute, id)
+	if err != nil {
+		return nil, 0, fmt.Errorf("cannot lookup %s: %w", docType, err)
+	}
+
+	searchItems := make(SearchItems, 0)
+	if attribute.IsResolvableID() {
+		searchItems = append(searchItems, NewSearchItem(docType, attribute, id))
+	}
+
+	return estv.lookupTitle(query.WithType(docType), searchItems, 0, 0)
+}
+
 type LookupMovieParams struct {
 	LookupCommonParams
 	Year uint16
 }
 
+// LookupMovie looks up a movie, unless an override pins the lookup to a movie. The year is
+// optional and scores movies of that year, and less so those within elastictv.movie.year_range
+// years of it, higher than movies of other years.
 func (estv ElasticTV) LookupMovie(params LookupMovieParams) (*Title, float64, error) {
+	if title, score, found, err := estv.lookupOverride(MovieType, params.LookupCommonParams, params.Year); found {
+		return title, score, err
+	}
+
 	query := params.LookupCommonParams.getCommonTitleQuery().
-		WithYearRange(params.Year, 1).
+		WithYearProximity(params.Year, MovieYearTolerance()).
 		WithType(MovieType).
 		WithIMDbID(params.IMDbID)
 
@@ -148,3 +253,26 @@ func (estv ElasticTV) getSearchItemsForMovieLookup(params LookupMovieParams) Sea
 
 	return params.LookupCommonParams.getSearchItemsFromDetails(MovieType, params.Year)
 }
+
+// MovieYearTolerance returns the number of years, configured by elastictv.movie.year_range, a
+// movie can be released before or after the year it is looked up with, since release dates
+// differ between countries.
+func MovieYearTolerance() uint16 {
+	if viper.IsSet("elastictv.movie.year_range") {
+		return uint16(viper.GetUint("elastictv.movie.year_range"))
+	}
+
+	return defaultMovieYearRange
+}
+
+// IsMovieYearInRange reports whether a movie released in year matches a lookup of the given year.
+// Any year matches lookups without a year.
+func IsMovieYearInRange(year, lookupYear uint16) bool {
+	if lookupYear == 0 {
+		return true
+	}
+
+	from, to := yearRange(lookupYear, MovieYearTolerance())
+
+	return year >= from && year <= to
+}
@@ -0,0 +1,116 @@
+package elastictv
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+// lookupEpisodeByAbsoluteNo looks up an episode by its absolute number. When the episode is not
+// cached, its season and episode number are mapped from the absolute numbering of the tv show.
+func (estv ElasticTV) lookupEpisodeByAbsoluteNo(tvshow *Title, score float64, absoluteNo uint16) (*Title, *Episode, float64, error) {
+	query := NewQuery().WithTVShowIDs(tvshow.IDs).WithAbsoluteEpisodeNumber(absoluteNo)
+	searchItem := NewSearchItem(EpisodeType, TMDbIDSearchAttribute, tvshow.IDs.TMDb)
+
+	episode, _ := estv.getEpisode(query, searchItem)
+	if episode != nil && !estv.RequiresUpdate(episode.Timestamp) {
+		return tvshow, episode, score, nil
+	}
+
+	numbering, err := estv.getAbsoluteNumbering(tvshow)
+	if err != nil {
+		return tvshow, episode, score, err
+	}
+
+	for _, numbered := range numbering {
+		if numbered.AbsoluteNo != absoluteNo {
+			continue
+		}
+
+		_, episode, _, err := estv.lookupTVShowEpisode(tvshow, score, numbered.SeasonNo, numbered.EpisodeNo)
+		if episode == nil {
+			return tvshow, nil, score, err
+		}
+
+		// Index the absolute numbers once the season of the episode is cached
+		if indexErr := estv.indexAbsoluteNumbers(tvshow, numbering); indexErr != nil {
+			err = multierror.Append(err, indexErr)
+		}
+
+		episode.AbsoluteNo = absoluteNo
+
+		return tvshow, episode, score, err
+	}
+
+	return tvshow, nil, score, fmt.Errorf("episode %d of tv show [ %s ] not found in absolute numbering",
+		absoluteNo, tvshow.Title)
+}
+
+// AbsoluteEpisodeNo returns the absolute number of an episode of a tv show from its season and
+// episode number.
+func (estv ElasticTV) AbsoluteEpisodeNo(tvshow *Title, seasonNo, episodeNo uint16) (uint16, error) {
+	_, episode, _, err := estv.lookupTVShowEpisode(tvshow, 0, seasonNo, episodeNo)
+	if episode != nil && episode.AbsoluteNo > 0 {
+		return episode.AbsoluteNo, nil
+	}
+
+	numbering, numberingErr := estv.getAbsoluteNumbering(tvshow)
+	if numberingErr != nil {
+		return 0, multierror.Append(err, numberingErr)
+	}
+
+	for _, numbered := range numbering {
+		if numbered.SeasonNo == seasonNo && numbered.EpisodeNo == episodeNo {
+			return numbered.AbsoluteNo, estv.indexAbsoluteNumbers(tvshow, numbering)
+		}
+	}
+
+	return 0, fmt.Errorf("episode S%02dE%02d of tv show [ %s ] has no absolute number",
+		seasonNo, episodeNo, tvshow.Title)
+}
+
+// getAbsoluteNumbering gets the absolute numbering of a tv show from the first provider which
+// supports it.
+func (estv ElasticTV) getAbsoluteNumbering(tvshow *Title) ([]Episode, error) {
+	var provider AbsoluteNumberingProvider
+
+	for _, p := range estv.Providers {
+		if numberingProvider, ok := p.(AbsoluteNumberingProvider); ok {
+			provider = numberingProvider
+
+			break
+		}
+	}
+
+	if provider == nil {
+		return nil, fmt.Errorf("%w [ absolute numbering of %s ]", ErrNoProvider, tvshow.Title)
+	}
+
+	return provider.GetAbsoluteNumbering(tvshow.IDs)
+}
+
+// indexAbsoluteNumbers adds the absolute numbers to the cached episodes of a tv show.
+func (estv ElasticTV) indexAbsoluteNumbers(tvshow *Title, numbering []Episode) error {
+	existing, err := estv.getEpisodeRecords(NewQuery().WithTVShowIDs(tvshow.IDs))
+	if err != nil {
+		return err
+	}
+
+	docs := make([]BulkDocument, 0)
+
+	for _, numbered := range numbering {
+		recordID, current := existing.find(numbered.SeasonNo, numbered.EpisodeNo)
+		if recordID == "" || current.AbsoluteNo == numbered.AbsoluteNo {
+			continue
+		}
+
+		current.AbsoluteNo = numbered.AbsoluteNo
+		docs = append(docs, BulkDocument{ID: recordID, Document: current})
+	}
+
+	if err := estv.BulkIndex(estv.Index.Episode, docs); err != nil {
+		return err
+	}
+
+	return estv.RefreshIndices(estv.Index.Episode)
+}
@@ -0,0 +1,135 @@
+package elastictv
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+// maxUpcomingEpisodes is the maximum number of episodes returned by UpcomingEpisodes.
+const maxUpcomingEpisodes = 1000
+
+// NextEpisode returns the next episode of a tv show which has not aired yet, including episodes
+// airing today, or nil when no episode is scheduled.
+func (estv ElasticTV) NextEpisode(tvshow *Title) (*Episode, error) {
+	today := time.Now().UTC().Format(airDateFormat)
+
+	return estv.getScheduledEpisode(tvshow,
+		NewQuery().WithTVShowIDs(tvshow.IDs).WithAirDateRange(today, "").SortByAirDate(true))
+}
+
+// PreviousEpisode returns the last episode of a tv show which aired before today, or nil when
+// no episode has aired yet.
+func (estv ElasticTV) PreviousEpisode(tvshow *Title) (*Episode, error) {
+	yesterday := time.Now().UTC().AddDate(0, 0, -1).Format(airDateFormat)
+
+	return estv.getScheduledEpisode(tvshow,
+		NewQuery().WithTVShowIDs(tvshow.IDs).WithAirDateRange("", yesterday).SortByAirDate(false))
+}
+
+// UpcomingEpisodes returns the episodes of the tv shows which air from and to the given dates,
+// in the order they air.
+func (estv ElasticTV) UpcomingEpisodes(tvshows []*Title, from, to time.Time) (Episodes, error) {
+	var errors *multierror.Error
+
+	tmdbIDs := make([]int, 0, len(tvshows))
+
+	for _, tvshow := range tvshows {
+		if tvshow.IDs.TMDb == 0 {
+			errors = multierror.Append(errors, fmt.Errorf("tv show [ %s ] has no TMDb ID", tvshow.Title))
+
+			continue
+		}
+
+		if searchErrors := estv.searchLatestSeason(tvshow.IDs); searchErrors != nil {
+			errors = multierror.Append(errors, searchErrors)
+		}
+
+		tmdbIDs = append(tmdbIDs, tvshow.IDs.TMDb)
+	}
+
+	if len(tmdbIDs) == 0 {
+		return nil, multierror.Append(errors, fmt.Errorf("no tv shows to get upcoming episodes of"))
+	}
+
+	query := NewQuery().
+		WithTVShowTMDbIDs(tmdbIDs...).
+		WithAirDateRange(from.Format(airDateFormat), to.Format(airDateFormat)).
+		SortByAirDate(true)
+
+	result, err := estv.search(query, estv.Index.Episode, maxUpcomingEpisodes)
+	if err != nil {
+		return nil, multierror.Append(errors, err)
+	}
+
+	episodes := make(Episodes, 0, len(result.Hits.Hits))
+
+	for _, hit := range result.Hits.Hits {
+		episode := Episode{}
+		if err := json.Unmarshal(hit.Source, &episode); err != nil {
+			return nil, multierror.Append(errors, fmt.Errorf("error parsing source: %w", err))
+		}
+
+		episodes = append(episodes, episode)
+	}
+
+	return episodes, errors.ErrorOrNil()
+}
+
+func (estv ElasticTV) getScheduledEpisode(tvshow *Title, query *Query) (*Episode, error) {
+	errors := estv.searchLatestSeason(tvshow.IDs)
+
+	episode := &Episode{}
+
+	recordID, err := estv.GetRecord(query, estv.Index.Episode, episode)
+	if err != nil {
+		return nil, multierror.Append(errors, err)
+	}
+
+	if recordID == "" {
+		return nil, errors.ErrorOrNil()
+	}
+
+	return episode, errors.ErrorOrNil()
+}
+
+// searchLatestSeason fetches the latest season of a tv show, which is where episodes are
+// scheduled, once the season of the last cached episode has expired.
+func (estv ElasticTV) searchLatestSeason(tvshowIDs IDs) *multierror.Error {
+	if tvshowIDs.TMDb == 0 {
+		return nil
+	}
+
+	latest := &Episode{}
+
+	query := NewQuery().WithTVShowIDs(tvshowIDs).SortByAirDate(false)
+
+	recordID, err := estv.GetRecord(query, estv.Index.Episode, latest)
+	if err != nil {
+		return multierror.Append(nil, err)
+	}
+
+	seasonItem := NewSearchItem(SeasonType, TMDbIDSearchAttribute, tvshowIDs.TMDb)
+	seasonQuery := NewQuery().WithSearchItem(seasonItem.WithSeasonNo(latest.SeasonNo))
+
+	if recordID != "" && !estv.IsRecordExpired(seasonQuery, estv.Index.Search) {
+		return nil
+	}
+
+	seasons, err := estv.getSeasons(tvshowIDs)
+	if err != nil {
+		return multierror.Append(nil, err)
+	}
+
+	var latestSeasonNo uint16
+
+	for _, season := range seasons {
+		if season.SeasonNo > latestSeasonNo {
+			latestSeasonNo = season.SeasonNo
+		}
+	}
+
+	return estv.searchSeason(seasonItem.WithSeasonNo(latestSeasonNo))
+}
@@ -2,79 +2,103 @@ package elastictv
 
 import (
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/hashicorp/go-multierror"
-	"github.com/spf13/viper"
 )
 
+const airDateFormat = "2006-01-02"
+
 type LookupEpisodeParams struct {
 	LookupCommonParams
 	SeasonNo  uint16
 	EpisodeNo uint16
+	// LastEpisodeNo is the last episode of files with multiple episodes (ex S03E01-E02), used by LookupEpisodes
+	LastEpisodeNo uint16
+	// AirDate (ex 2024-03-15) looks up episodes of daily shows instead of season and episode number
+	AirDate string
+	// AbsoluteNo looks up episodes numbered from the first episode of the tv show, as used by anime
+	AbsoluteNo uint16
+	// EpisodeTitle looks up episodes, including specials, by their title when the episode number is not set
+	EpisodeTitle string
 }
 
+// LookupEpisode looks up an episode and its tv show. The IMDb ID can be the ID of the episode
+// or of the tv show, in which case the episode is looked up by its season and episode number,
+// its air date or its absolute number.
 func (estv ElasticTV) LookupEpisode(params LookupEpisodeParams) (*Title, *Episode, float64, error) {
 	if params.IMDbID != "" {
-		return estv.lookupEpisodeFromEpisodeIMDbID(params)
+		if tvshow, episode, score, err := estv.lookupEpisodeFromEpisodeIMDbID(params.IMDbID); episode != nil {
+			return tvshow, episode, score, err
+		}
+
+		if tvshow, score, _ := estv.lookupTVShowFromIMDbID(params.IMDbID); tvshow != nil {
+			return estv.lookupEpisodeOfTVShow(tvshow, score, params)
+		}
 	}
 
-	return estv.lookupEpisodeFromDetails(params)
+	tvshow, score, err := estv.lookupTVShowFromDetails(LookupTVShowParams{LookupCommonParams: params.LookupCommonParams})
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	return estv.lookupEpisodeOfTVShow(tvshow, score, params)
 }
 
-func (estv ElasticTV) lookupEpisodeFromEpisodeIMDbID(params LookupEpisodeParams) (*Title, *Episode, float64, error) {
-	episodeQuery := NewQuery().WithIMDbID(params.IMDbID)
-	episodeSearchItem := NewSearchItem(EpisodeType, IMDbIDSearchAttribute, params.IMDbID)
+func (estv ElasticTV) lookupEpisodeOfTVShow(tvshow *Title, score float64, params LookupEpisodeParams) (*Title, *Episode, float64, error) {
+	if params.AirDate != "" {
+		episode, err := estv.lookupEpisodeByAirDate(tvshow, params.AirDate)
 
-	episode, _ := estv.lookupEpisodeDetails(episodeQuery, episodeSearchItem)
-	// If episode was not found by IMDb ID lookup, lookup using details
-	if episode == nil {
-		return estv.lookupEpisodeFromDetails(params)
+		return tvshow, episode, score, err
 	}
 
-	tvshow, score, err := estv.lookupTitle(
-		NewQuery().WithTMDbID(episode.TVShowIDs.TMDb).WithType(TvShowType),
-		SearchItems{NewSearchItem(TvShowType, TMDbIDSearchAttribute, episode.TVShowIDs.TMDb)},
-		0, 0,
-	)
-	if err != nil {
-		return nil, nil, score, err
+	if params.AbsoluteNo > 0 {
+		return estv.lookupEpisodeByAbsoluteNo(tvshow, score, params.AbsoluteNo)
 	}
 
-	return tvshow, episode, score, err
+	if params.EpisodeTitle != "" && params.EpisodeNo == 0 {
+		episode, _, err := estv.LookupEpisodeTitle(tvshow, params.EpisodeTitle)
+
+		return tvshow, episode, score, err
+	}
+
+	return estv.lookupTVShowEpisode(tvshow, score, params.SeasonNo, params.EpisodeNo)
 }
 
-func (estv ElasticTV) lookupEpisodeFromDetails(params LookupEpisodeParams) (*Title, *Episode, float64, error) {
-	query := params.LookupCommonParams.getCommonTitleQuery().
-		WithType(TvShowType)
+// lookupEpisodeFromEpisodeIMDbID looks up an episode by its IMDb ID, returning a nil episode
+// if the IMDb ID is not of an episode.
+func (estv ElasticTV) lookupEpisodeFromEpisodeIMDbID(imdbID string) (*Title, *Episode, float64, error) {
+	episodeQuery := NewQuery().WithIMDbID(imdbID)
+	episodeSearchItem := NewSearchItem(EpisodeType, IMDbIDSearchAttribute, imdbID)
 
-	minScore := viper.GetFloat64("elastictv.tvshow.min_score_credits")
-	if !params.LookupCommonParams.hasCredits() {
-		minScore = viper.GetFloat64("elastictv.tvshow.min_score_no_credits")
+	episode, _ := estv.lookupEpisodeDetails(episodeQuery, episodeSearchItem)
+	if episode == nil {
+		return nil, nil, 0, nil
 	}
 
-	tvshow, score, err := estv.lookupTitle(
-		query,
-		params.LookupCommonParams.getSearchItemsFromDetails(TvShowType, 0),
-		viper.GetFloat64("elastictv.movie.min_score_no_search"),
-		minScore,
-	)
+	tvshow, score, err := estv.lookupTVShowFromIDs(episode.TVShowIDs)
 	if err != nil {
-		return nil, nil, 0, err
+		return nil, nil, score, err
 	}
 
-	if params.SeasonNo == 0 || params.EpisodeNo == 0 {
-		return tvshow, nil, score, err
+	return tvshow, episode, score, err
+}
+
+func (estv ElasticTV) lookupTVShowEpisode(tvshow *Title, score float64, seasonNo, episodeNo uint16) (*Title, *Episode, float64, error) {
+	if episodeNo == 0 {
+		return tvshow, nil, score, nil
 	}
 
-	query = NewQuery().WithTVShowTMDbID(tvshow.IDs.TMDb).
-		WithSeasonNumber(params.SeasonNo).
-		WithEpisodeNumber(params.EpisodeNo)
+	query := NewQuery().WithTVShowIDs(tvshow.IDs).
+		WithSeasonNumber(seasonNo).
+		WithEpisodeNumber(episodeNo)
 
 	searchParams := SearchItem{
 		Attribute: TMDbIDSearchAttribute,
 		Query:     tvshow.IDs.TMDb,
-		SeasonNo:  params.SeasonNo,
-		EpisodeNo: params.EpisodeNo,
+		SeasonNo:  seasonNo,
+		EpisodeNo: episodeNo,
 		Type:      EpisodeType,
 	}
 
@@ -90,18 +114,38 @@ func (estv ElasticTV) lookupEpisodeDetails(query *Query, searchItem SearchItem)
 	}
 
 	var errors *multierror.Error
-	for _, provider := range estv.Providers {
-		if err := provider.SearchEpisode(searchItem); err != nil {
-			errors = multierror.Append(errors, err)
+
+	// Fetch the whole season of a missing episode, which also caches the other episodes of the season
+	if episode == nil && searchItem.Attribute == TMDbIDSearchAttribute && searchItem.EpisodeNo > 0 {
+		errors = multierror.Append(errors, estv.searchSeason(NewSearchItem(SeasonType, searchItem.Attribute,
+			searchItem.Query).WithSeasonNo(searchItem.SeasonNo)))
+
+		if episode, _ := estv.getEpisode(query, searchItem); episode != nil {
+			return episode, errors.ErrorOrNil()
+		}
+	}
+
+	isFound := func() bool {
+		if err := estv.RefreshIndices(estv.Index.Episode); err != nil {
+			return false
 		}
+
+		episode, _ := estv.getEpisode(query, searchItem)
+
+		return episode != nil
 	}
 
+	searchErrors, served := estv.searchProviders(SearchItems{searchItem}, isFound, SearchableProvider.SearchEpisode)
+	errors = multierror.Append(errors, searchErrors...)
+
 	if err := estv.RefreshIndices(estv.Index.Episode); err != nil {
 		errors = multierror.Append(errors, err)
 	}
 
-	if err := estv.indexSearchItem(searchItem); err != nil {
-		errors = multierror.Append(errors, err)
+	if served.contains(searchItem) {
+		if err := estv.indexSearchItem(searchItem); err != nil {
+			errors = multierror.Append(errors, err)
+		}
 	}
 
 	episode, err := estv.getEpisode(query, searchItem)
@@ -122,3 +166,76 @@ func (estv ElasticTV) getEpisode(query *Query, searchItem SearchItem) (*Episode,
 
 	return nil, fmt.Errorf("episode not found [ %s ]", searchItem)
 }
+
+// lookupEpisodeByAirDate looks up an episode of a tv show by the date it aired. When the episode
+// is not cached, the season which was airing on that date is fetched, together with the season
+// before it in case the season air dates are of its premiere rather than of all its episodes.
+func (estv ElasticTV) lookupEpisodeByAirDate(tvshow *Title, airDate string) (*Episode, error) {
+	if _, err := time.Parse(airDateFormat, airDate); err != nil {
+		return nil, fmt.Errorf("invalid air date [ %s ]: %w", airDate, err)
+	}
+
+	query := NewQuery().WithTVShowIDs(tvshow.IDs).WithAirDate(airDate)
+	searchItem := NewSearchItem(EpisodeType, TMDbIDSearchAttribute, tvshow.IDs.TMDb)
+
+	episode, _ := estv.getEpisode(query, searchItem)
+	if episode != nil && !estv.RequiresUpdate(episode.Timestamp) {
+		return episode, nil
+	}
+
+	if tvshow.IDs.TMDb == 0 {
+		return episode, fmt.Errorf("tv show [ %s ] has no TMDb ID to get its seasons", tvshow.Title)
+	}
+
+	seasonNumbers, err := estv.getSeasonsAiringOn(tvshow.IDs, airDate)
+	if err != nil {
+		return episode, err
+	}
+
+	var errors *multierror.Error
+
+	for _, seasonNo := range seasonNumbers {
+		searchErrors := estv.searchSeason(NewSearchItem(SeasonType, TMDbIDSearchAttribute, tvshow.IDs.TMDb).
+			WithSeasonNo(seasonNo))
+		errors = multierror.Append(errors, searchErrors)
+
+		if episode, err := estv.getEpisode(query, searchItem); err == nil {
+			return episode, nil
+		}
+	}
+
+	episode, err = estv.getEpisode(query, searchItem)
+
+	return episode, multierror.Append(errors, err).ErrorOrNil()
+}
+
+// getSeasonsAiringOn returns the numbers of the last season which started airing on or before
+// the air date and of the season before it. Specials are skipped since they span all seasons.
+func (estv ElasticTV) getSeasonsAiringOn(tvshowIDs IDs, airDate string) ([]uint16, error) {
+	seasons, err := estv.getSeasons(tvshowIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Air dates are formatted as YYYY-MM-DD so they can be compared as strings
+	sort.Slice(seasons, func(i, j int) bool { return seasons[i].AirDate > seasons[j].AirDate })
+
+	seasonNumbers := make([]uint16, 0, 2)
+
+	for _, season := range seasons {
+		if season.SeasonNo == 0 || season.AirDate == "" || season.AirDate > airDate {
+			continue
+		}
+
+		seasonNumbers = append(seasonNumbers, season.SeasonNo)
+		if len(seasonNumbers) == cap(seasonNumbers) {
+			break
+		}
+	}
+
+	if len(seasonNumbers) == 0 {
+		return nil, fmt.Errorf("no season aired on or before %s", airDate)
+	}
+
+	return seasonNumbers, nil
+}
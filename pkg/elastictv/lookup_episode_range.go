@@ -0,0 +1,108 @@
+package elastictv
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+// episodePartRegex matches the part suffix of titles of episodes split in parts (ex Pilot (1) or
+// Pilot, Part 1).
+var episodePartRegex = regexp.MustCompile(`(?i)\s*(\(\d+\)|,?\s+part\s+(\d+|[ivx]+|one|two|three))\s*$`)
+
+type Episodes []Episode
+
+// LookupEpisodes looks up the episodes of files which contain multiple episodes of a season,
+// from EpisodeNo to LastEpisodeNo. Without LastEpisodeNo only the episode of LookupEpisode is
+// returned.
+func (estv ElasticTV) LookupEpisodes(params LookupEpisodeParams) (*Title, Episodes, float64, error) {
+	if params.LastEpisodeNo > 0 && params.LastEpisodeNo < params.EpisodeNo {
+		return nil, nil, 0, fmt.Errorf("last episode %d is before first episode %d",
+			params.LastEpisodeNo, params.EpisodeNo)
+	}
+
+	tvshow, episode, score, err := estv.LookupEpisode(params)
+	if episode == nil {
+		return tvshow, nil, score, err
+	}
+
+	var errors *multierror.Error
+	if err != nil {
+		errors = multierror.Append(errors, err)
+	}
+
+	episodes := Episodes{*episode}
+
+	for episodeNo := int(episode.EpisodeNo) + 1; episodeNo <= int(params.LastEpisodeNo); episodeNo++ {
+		_, next, _, err := estv.lookupTVShowEpisode(tvshow, score, episode.SeasonNo, uint16(episodeNo))
+		if next == nil {
+			errors = multierror.Append(errors, err)
+
+			continue
+		}
+
+		episodes = append(episodes, *next)
+	}
+
+	return tvshow, episodes, score, errors.ErrorOrNil()
+}
+
+// Title returns the title of the episodes for tagging. Episodes split in parts share the title
+// without the part (ex Pilot for Pilot (1) and Pilot (2)), otherwise their titles are joined.
+func (e Episodes) Title() string {
+	titles := make([]string, 0, len(e))
+	stems := make([]string, 0, len(e))
+
+	for _, episode := range e {
+		if episode.Title == "" || containsString(titles, episode.Title) {
+			continue
+		}
+
+		titles = append(titles, episode.Title)
+		if stem := episodePartRegex.ReplaceAllString(episode.Title, ""); !containsString(stems, stem) {
+			stems = append(stems, stem)
+		}
+	}
+
+	if len(titles) > 1 && len(stems) == 1 && stems[0] != "" {
+		return stems[0]
+	}
+
+	return strings.Join(titles, " / ")
+}
+
+// Description returns the descriptions of the episodes joined for each source and language.
+func (e Episodes) Description() Descriptions {
+	combined := make(Descriptions, 0)
+
+	for _, episode := range e {
+		for _, description := range episode.Description {
+			if description.Text == "" {
+				continue
+			}
+
+			index := combined.index(description.Source, description.Language)
+			if index < 0 {
+				combined = append(combined, description)
+
+				continue
+			}
+
+			combined[index].Text += "\n\n" + description.Text
+		}
+	}
+
+	return combined
+}
+
+func (d Descriptions) index(source, language string) int {
+	for i, description := range d {
+		if description.Source == source && description.Language == language {
+			return i
+		}
+	}
+
+	return -1
+}
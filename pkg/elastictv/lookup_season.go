@@ -0,0 +1,193 @@
+package elastictv
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+// LookupSeason looks up a season of a tv show, fetching the season with all its episodes
+// from the providers when it is not cached.
+func (estv ElasticTV) LookupSeason(tvshow *Title, seasonNo uint16) (*Season, error) {
+	if estv.Index.Season == "" {
+		return nil, fmt.Errorf("season index is not configured")
+	}
+
+	query := NewQuery().WithTVShowIDs(tvshow.IDs).WithSeasonNumber(seasonNo)
+
+	season := &Season{}
+
+	recordID, err := estv.GetRecord(query, estv.Index.Season, season)
+	if err != nil {
+		return nil, err
+	}
+
+	if recordID != "" && !estv.RequiresUpdate(season.Timestamp) {
+		return season, nil
+	}
+
+	errors := estv.searchSeason(NewSearchItem(SeasonType, TMDbIDSearchAttribute, tvshow.IDs.TMDb).WithSeasonNo(seasonNo))
+
+	if err := estv.RefreshIndices(estv.Index.Season); err != nil {
+		errors = multierror.Append(errors, err)
+	}
+
+	recordID, err = estv.GetRecord(query, estv.Index.Season, season)
+	if err != nil {
+		errors = multierror.Append(errors, err)
+	}
+
+	if recordID == "" {
+		return nil, multierror.Append(errors, fmt.Errorf("season %d not found", seasonNo))
+	}
+
+	return season, errors.ErrorOrNil()
+}
+
+// searchSeason fetches a season with all its episodes from the providers which support it,
+// unless the season was fetched recently.
+func (estv ElasticTV) searchSeason(searchItem SearchItem) *multierror.Error {
+	var errors *multierror.Error
+
+	if !estv.IsRecordExpired(NewQuery().WithSearchItem(searchItem), estv.Index.Search) {
+		return nil
+	}
+
+	searchErrors, served := estv.searchProviders(SearchItems{searchItem}, func() bool { return false },
+		func(provider SearchableProvider, item SearchItem) error {
+			seasonProvider, ok := provider.(SeasonSearchableProvider)
+			if !ok {
+				return NewNotSupportedError(provider.Name(), item)
+			}
+
+			return seasonProvider.SearchSeason(item)
+		})
+	errors = multierror.Append(errors, searchErrors...)
+
+	if served.contains(searchItem) {
+		if err := estv.indexSearchItem(searchItem); err != nil {
+			errors = multierror.Append(errors, err)
+		}
+	}
+
+	if err := estv.RefreshIndices(estv.Index.Episode); err != nil {
+		errors = multierror.Append(errors, err)
+	}
+
+	return errors
+}
+
+// seasonListNo is the season number of the search items which record when the seasons of a tv
+// show were listed, which no tv show reaches so they are not mistaken for a search of a season.
+const seasonListNo = math.MaxUint16
+
+// maxSeasons is the maximum number of cached seasons of a tv show which are read.
+const maxSeasons = 1000
+
+// getSeasons lists the seasons of a tv show from the season index when they were listed recently,
+// and otherwise from the first provider which can fetch seasons, caching the seasons it lists.
+// Seasons are only read from the index once listed, since seasons are also cached one at a time
+// when their episodes are looked up.
+func (estv ElasticTV) getSeasons(tvshowIDs IDs) ([]Season, error) {
+	searchItem := NewSearchItem(SeasonType, TMDbIDSearchAttribute, tvshowIDs.TMDb).WithSeasonNo(seasonListNo)
+
+	if estv.Index.Season != "" && !estv.IsRecordExpired(NewQuery().WithSearchItem(searchItem), estv.Index.Search) {
+		seasons, err := estv.getSeasonRecords(tvshowIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(seasons) > 0 {
+			return seasons, nil
+		}
+	}
+
+	for _, provider := range estv.Providers {
+		seasonProvider, ok := provider.(SeasonSearchableProvider)
+		if !ok {
+			continue
+		}
+
+		seasons, err := seasonProvider.GetSeasons(tvshowIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := estv.cacheSeasons(searchItem, seasons); err != nil {
+			log.Printf("Error caching seasons of tvshow [ %d ]: %s", tvshowIDs.TMDb, err)
+		}
+
+		return seasons, nil
+	}
+
+	return nil, fmt.Errorf("%w [ seasons of tv show with TMDb ID %d ]", ErrNoProvider, tvshowIDs.TMDb)
+}
+
+func (estv ElasticTV) getSeasonRecords(tvshowIDs IDs) ([]Season, error) {
+	result, err := estv.search(NewQuery().WithTVShowIDs(tvshowIDs), estv.Index.Season, maxSeasons)
+	if err != nil {
+		return nil, err
+	}
+
+	seasons := make([]Season, 0, len(result.Hits.Hits))
+
+	for _, hit := range result.Hits.Hits {
+		season := Season{}
+		if err := json.Unmarshal(hit.Source, &season); err != nil {
+			return nil, fmt.Errorf("error parsing source: %w", err)
+		}
+
+		seasons = append(seasons, season)
+	}
+
+	return seasons, nil
+}
+
+// cacheSeasons indexes the seasons listed by a provider and records when they were listed.
+func (estv ElasticTV) cacheSeasons(searchItem SearchItem, seasons []Season) error {
+	if estv.Index.Season == "" || len(seasons) == 0 {
+		return nil
+	}
+
+	var errors *multierror.Error
+
+	for _, season := range seasons {
+		if err := estv.UpsertSeason(season); err != nil {
+			errors = multierror.Append(errors, err)
+		}
+	}
+
+	if errors != nil {
+		return errors
+	}
+
+	if err := estv.RefreshIndices(estv.Index.Season); err != nil {
+		return err
+	}
+
+	return estv.indexSearchItem(searchItem)
+}
+
+// searchSeasons fetches all seasons of a tv show with their episodes, skipping seasons which
+// were fetched recently.
+func (estv ElasticTV) searchSeasons(tvshowIDs IDs) *multierror.Error {
+	seasons, err := estv.getSeasons(tvshowIDs)
+	if err != nil {
+		return multierror.Append(nil, err)
+	}
+
+	var errors *multierror.Error
+
+	for _, season := range seasons {
+		searchErrors := estv.searchSeason(NewSearchItem(SeasonType, TMDbIDSearchAttribute, tvshowIDs.TMDb).
+			WithSeasonNo(season.SeasonNo))
+		if searchErrors != nil {
+			errors = multierror.Append(errors, searchErrors)
+		}
+	}
+
+	return errors
+}
@@ -0,0 +1,77 @@
+package elastictv
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type testSeasonProvider struct {
+	testProvider
+	listed *int
+}
+
+func (p testSeasonProvider) SearchSeason(SearchItem) error { return nil }
+
+func (p testSeasonProvider) GetSeasons(tvshowIDs IDs) ([]Season, error) {
+	*p.listed++
+
+	return []Season{
+		{SeasonNo: 1, AirDate: "2008-01-20", TVShowIDs: tvshowIDs, Source: p.name},
+		{SeasonNo: 2, AirDate: "2009-03-08", TVShowIDs: tvshowIDs, Source: p.name},
+	}, nil
+}
+
+func TestGetSeasons(t *testing.T) {
+	tests := []struct {
+		name       string
+		listed     bool
+		wantListed int
+		wantCached bool
+	}{
+		{"listed recently", true, 0, false},
+		{"not listed", false, 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			estv, requests := newTestElasticTV(t, func(request esRequest) string {
+				switch {
+				case request.Path == "/search/_search" && strings.Contains(request.Body, `"season":65535`) && tt.listed:
+					return fmt.Sprintf(`{"hits":{"total":{"value":1},"hits":[{"_id":"1","_source":{"@timestamp":%q}}]}}`,
+						CurrentTimestamp())
+				case request.Path == "/seasons/_search" && tt.listed:
+					return `{"hits":{"total":{"value":1},"hits":[{"_id":"1","_source":{"season":1,"tvshow_ids":{"tmdb":1396}}}]}}`
+				case strings.HasSuffix(request.Path, "/_search"):
+					return noHits
+				default:
+					return `{}`
+				}
+			})
+
+			listed := 0
+			estv.Providers = append(estv.Providers, testSeasonProvider{testProvider{name: "test"}, &listed})
+
+			seasons, err := estv.getSeasons(IDs{TMDb: 1396})
+			if err != nil || len(seasons) == 0 {
+				t.Fatalf("getSeasons() = %+v, %v", seasons, err)
+			}
+
+			if listed != tt.wantListed {
+				t.Errorf("provider listed seasons %d times, want %d", listed, tt.wantListed)
+			}
+
+			indexed := make(map[string]int)
+			for _, request := range *requests {
+				if strings.HasSuffix(request.Path, "/_doc") {
+					indexed[strings.TrimSuffix(request.Path, "/_doc")]++
+				}
+			}
+
+			// Listed seasons are cached along with when they were listed
+			if cached := indexed["/seasons"] == 2 && indexed["/search"] == 1; cached != tt.wantCached {
+				t.Errorf("indexed documents %v, want cached %t", indexed, tt.wantCached)
+			}
+		})
+	}
+}
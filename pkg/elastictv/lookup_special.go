@@ -0,0 +1,82 @@
+package elastictv
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/spf13/viper"
+)
+
+// defaultEpisodeTitleMinScore is the minimum score of an episode title match unless
+// elastictv.episode.min_score_title is set, to reject weak fuzzy matches of a single word.
+const defaultEpisodeTitleMinScore = 5
+
+// LookupSpecial looks up a special of a tv show by its title, since specials are usually released
+// under their name rather than their number. Specials are kept in season 0, which is fetched from
+// the providers when it is not cached.
+func (estv ElasticTV) LookupSpecial(tvshow *Title, title string) (*Episode, float64, error) {
+	var errors *multierror.Error
+
+	if tvshow.IDs.TMDb > 0 {
+		errors = estv.searchSeason(NewSearchItem(SeasonType, TMDbIDSearchAttribute, tvshow.IDs.TMDb).
+			WithSeasonNo(0))
+	}
+
+	episode, score, err := estv.matchEpisodeTitle(tvshow,
+		NewQuery().WithTVShowIDs(tvshow.IDs).WithSeasonNumber(0).WithEpisodeTitle(title), title)
+	if episode == nil {
+		return nil, score, multierror.Append(errors, err)
+	}
+
+	return episode, score, nil
+}
+
+// LookupEpisodeTitle looks up an episode of a tv show by its title, for files which have the
+// title of the episode but wrong or missing numbers. All seasons of the tv show are fetched
+// from the providers unless a cached episode matches the title with at least the score of
+// elastictv.episode.min_score_title. The score is of the match of the title.
+func (estv ElasticTV) LookupEpisodeTitle(tvshow *Title, title string) (*Episode, float64, error) {
+	query := NewQuery().WithTVShowIDs(tvshow.IDs).WithEpisodeTitle(title)
+
+	if episode, score, _ := estv.matchEpisodeTitle(tvshow, query, title); episode != nil {
+		return episode, score, nil
+	}
+
+	var errors *multierror.Error
+
+	if tvshow.IDs.TMDb > 0 {
+		errors = estv.searchSeasons(tvshow.IDs)
+	}
+
+	episode, score, err := estv.matchEpisodeTitle(tvshow, query, title)
+	if episode == nil {
+		return nil, score, multierror.Append(errors, err)
+	}
+
+	return episode, score, nil
+}
+
+// matchEpisodeTitle returns the episode which best matches the title when its score reaches
+// elastictv.episode.min_score_title, which defaults to defaultEpisodeTitleMinScore.
+func (estv ElasticTV) matchEpisodeTitle(tvshow *Title, query *Query, title string) (*Episode, float64, error) {
+	episode := &Episode{}
+
+	score, err := estv.getRecordWithScore(query, estv.Index.Episode, episode)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if score == 0 || score < episodeTitleMinScore() {
+		return nil, score, fmt.Errorf("episode [ %s ] of tv show [ %s ] not found", title, tvshow.Title)
+	}
+
+	return episode, score, nil
+}
+
+func episodeTitleMinScore() float64 {
+	if viper.IsSet("elastictv.episode.min_score_title") {
+		return viper.GetFloat64("elastictv.episode.min_score_title")
+	}
+
+	return defaultEpisodeTitleMinScore
+}
@@ -0,0 +1,30 @@
+package elastictv
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestLookupTitleByEIDRID(t *testing.T) {
+	const eidrID = "10.5240/1489-49A2-3956-4B2D-FE16-5"
+
+	estv, _ := newTestElasticTV(t, func(request esRequest) string {
+		if request.Path != "/titles/_search" || !strings.Contains(request.Body, eidrID) {
+			return noHits
+		}
+
+		return fmt.Sprintf(`{"hits":{"total":{"value":1},"hits":[{"_id":"tt0133093","_score":1,"_source":`+
+			`{"title":"The Matrix","type":"movie","ids":{"eidr":%q},"@timestamp":%q}}]}}`, eidrID, CurrentTimestamp())
+	})
+
+	title, _, err := estv.LookupTitleByID(MovieType, EIDRIDSearchAttribute, eidrID)
+	if err != nil || title == nil || title.Title != "The Matrix" {
+		t.Errorf("LookupTitleByID() of cached title = %+v, %v, want The Matrix", title, err)
+	}
+
+	// EIDR IDs are not searched for since no provider resolves them
+	if title, _, err := estv.LookupTitleByID(MovieType, EIDRIDSearchAttribute, "10.5240/0000"); err == nil {
+		t.Errorf("LookupTitleByID() of uncached title = %+v, want not found", title)
+	}
+}
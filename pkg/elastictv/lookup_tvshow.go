@@ -0,0 +1,109 @@
+package elastictv
+
+import (
+	"fmt"
+
+	"github.com/spf13/viper"
+)
+
+const defaultTVShowYearRange = 1
+
+type LookupTVShowParams struct {
+	LookupCommonParams
+	// Year the tv show first aired
+	Year   uint16
+	TMDbID int
+	TVDbID int
+}
+
+// LookupTVShow looks up a tv show. The IMDb ID can be the ID of the tv show or of any of
+// its episodes.
+func (estv ElasticTV) LookupTVShow(params LookupTVShowParams) (*Title, float64, error) {
+	if params.TMDbID > 0 {
+		return estv.lookupTVShowFromIDs(IDs{TMDb: params.TMDbID})
+	}
+
+	if params.TVDbID > 0 {
+		return estv.lookupTVShowFromIDs(IDs{TVDb: params.TVDbID})
+	}
+
+	if params.IMDbID != "" {
+		if tvshow, score, err := estv.lookupTVShowFromIMDbID(params.IMDbID); tvshow != nil {
+			return tvshow, score, err
+		}
+
+		if tvshow, _, score, err := estv.lookupEpisodeFromEpisodeIMDbID(params.IMDbID); tvshow != nil {
+			return tvshow, score, err
+		}
+	}
+
+	return estv.lookupTVShowFromDetails(params)
+}
+
+func (estv ElasticTV) lookupTVShowFromIMDbID(imdbID string) (*Title, float64, error) {
+	return estv.lookupTitle(
+		NewQuery().WithIMDbID(imdbID).WithType(TvShowType),
+		SearchItems{NewSearchItem(TvShowType, IMDbIDSearchAttribute, imdbID)},
+		0, 0,
+	)
+}
+
+// lookupTVShowFromIDs looks up a tv show by the first of its IDs which is set.
+func (estv ElasticTV) lookupTVShowFromIDs(ids IDs) (*Title, float64, error) {
+	switch {
+	case ids.TMDb > 0:
+		return estv.lookupTitle(
+			NewQuery().WithTMDbID(ids.TMDb).WithType(TvShowType),
+			SearchItems{NewSearchItem(TvShowType, TMDbIDSearchAttribute, ids.TMDb)},
+			0, 0,
+		)
+	case ids.IMDb != "":
+		return estv.lookupTVShowFromIMDbID(ids.IMDb)
+	case ids.TVDb > 0:
+		return estv.lookupTitle(
+			NewQuery().WithTVDbID(ids.TVDb).WithType(TvShowType),
+			SearchItems{NewSearchItem(TvShowType, TVDbIDSearchAttribute, ids.TVDb)},
+			0, 0,
+		)
+	default:
+		return nil, 0, fmt.Errorf("tv show has no TMDb, IMDb or TVDb ID")
+	}
+}
+
+// lookupTVShowFromDetails looks up a tv show by its details, unless an override pins the lookup
+// to a tv show.
+func (estv ElasticTV) lookupTVShowFromDetails(params LookupTVShowParams) (*Title, float64, error) {
+	if tvshow, score, found, err := estv.lookupOverride(TvShowType, params.LookupCommonParams, params.Year); found {
+		return tvshow, score, err
+	}
+
+	query := params.LookupCommonParams.getCommonTitleQuery().
+		WithType(TvShowType)
+
+	if params.Year > 0 {
+		yearRange := defaultTVShowYearRange
+		if viper.IsSet("elastictv.tvshow.year_range") {
+			yearRange = viper.GetInt("elastictv.tvshow.year_range")
+		}
+
+		query = query.WithYearRange(params.Year, uint16(yearRange))
+	}
+
+	minScore := viper.GetFloat64("elastictv.tvshow.min_score_credits")
+	if !params.LookupCommonParams.hasCredits() {
+		minScore = viper.GetFloat64("elastictv.tvshow.min_score_no_credits")
+	}
+
+	// Configurations written before tv shows had their own threshold use the one of movies
+	minScoreNoSearch := viper.GetFloat64("elastictv.movie.min_score_no_search")
+	if viper.IsSet("elastictv.tvshow.min_score_no_search") {
+		minScoreNoSearch = viper.GetFloat64("elastictv.tvshow.min_score_no_search")
+	}
+
+	return estv.lookupTitle(
+		query,
+		params.LookupCommonParams.getSearchItemsFromDetails(TvShowType, params.Year),
+		minScoreNoSearch,
+		minScore,
+	)
+}
@@ -0,0 +1,585 @@
+package elastictv
+
+import (
+	"cmp"
+	"fmt"
+	"strings"
+)
+
+// CombinedRatingSource is the source of the rating computed from the ratings of all
+// other sources when the rating field uses the max or weighted merge policy.
+const CombinedRatingSource = "Combined"
+
+type MergePolicy string
+
+const (
+	// PriorityMergePolicy takes the value of the provider with the highest priority.
+	PriorityMergePolicy MergePolicy = "priority"
+	// ReplaceMergePolicy takes the value of the provider writing the record when it has one.
+	ReplaceMergePolicy MergePolicy = "replace"
+	// UnionMergePolicy combines the values of all providers.
+	UnionMergePolicy MergePolicy = "union"
+	// EarliestMergePolicy takes the lowest value (ex the earliest year).
+	EarliestMergePolicy MergePolicy = "earliest"
+	// LatestMergePolicy takes the highest value.
+	LatestMergePolicy MergePolicy = "latest"
+	// MaxMergePolicy keeps the ratings of all sources and adds the highest one as combined rating.
+	MaxMergePolicy MergePolicy = "max"
+	// WeightedMergePolicy keeps the ratings of all sources and adds their weighted average as
+	// combined rating.
+	WeightedMergePolicy MergePolicy = "weighted"
+)
+
+// MergeRule configures how a field is merged when several providers write the same record.
+// Providers lists the preferred providers in order, ahead of the order providers were added.
+type MergeRule struct {
+	Policy    MergePolicy        `mapstructure:"policy"`
+	Providers []string           `mapstructure:"providers"`
+	Weights   map[string]float32 `mapstructure:"weights"`
+}
+
+// Provenance records which provider supplied a field and when.
+type Provenance struct {
+	Source    string `json:"source,omitempty"`
+	Timestamp string `json:"@timestamp,omitempty"`
+}
+
+var (
+	defaultMergeRules = map[string]MergeRule{
+		"alias":       {Policy: UnionMergePolicy},
+		"country":     {Policy: UnionMergePolicy},
+		"credits":     {Policy: UnionMergePolicy},
+		"description": {Policy: UnionMergePolicy},
+		"genre":       {Policy: UnionMergePolicy},
+		"rating":      {Policy: UnionMergePolicy},
+	}
+	scalarMergePolicies = []MergePolicy{
+		PriorityMergePolicy, ReplaceMergePolicy, EarliestMergePolicy, LatestMergePolicy,
+	}
+	listMergePolicies   = []MergePolicy{PriorityMergePolicy, ReplaceMergePolicy, UnionMergePolicy}
+	ratingMergePolicies = []MergePolicy{
+		PriorityMergePolicy, ReplaceMergePolicy, UnionMergePolicy, MaxMergePolicy, WeightedMergePolicy,
+	}
+	mergeFieldPolicies = map[string][]MergePolicy{
+		"title":            scalarMergePolicies,
+		"year":             scalarMergePolicies,
+		"image":            scalarMergePolicies,
+		"language":         scalarMergePolicies,
+		"tagline":          scalarMergePolicies,
+		"air_date":         scalarMergePolicies,
+		"name":             scalarMergePolicies,
+		"episode_count":    scalarMergePolicies,
+		"season":           scalarMergePolicies,
+		"episode":          scalarMergePolicies,
+		"absolute_episode": scalarMergePolicies,
+		"alias":            listMergePolicies,
+		"country":          listMergePolicies,
+		"credits":          listMergePolicies,
+		"description":      listMergePolicies,
+		"genre":            listMergePolicies,
+		"rating":           ratingMergePolicies,
+	}
+)
+
+// newMergeRules returns the default merge rules overridden by the configured rules.
+func newMergeRules(configured map[string]MergeRule) (map[string]MergeRule, error) {
+	rules := make(map[string]MergeRule, len(defaultMergeRules)+len(configured))
+	for field, rule := range defaultMergeRules {
+		rules[field] = rule
+	}
+
+	for field, rule := range configured {
+		policies, ok := mergeFieldPolicies[field]
+		if !ok {
+			return nil, fmt.Errorf("cannot configure merge policy of unknown field %s", field)
+		}
+
+		if rule.Policy == "" {
+			rule.Policy = rules[field].Policy
+		}
+
+		if rule.Policy != "" && !containsPolicy(policies, rule.Policy) {
+			return nil, fmt.Errorf("merge policy %s is not supported for field %s", rule.Policy, field)
+		}
+
+		rules[field] = rule
+	}
+
+	return rules, nil
+}
+
+func containsPolicy(policies []MergePolicy, policy MergePolicy) bool {
+	for _, p := range policies {
+		if p == policy {
+			return true
+		}
+	}
+
+	return false
+}
+
+// fieldMerger merges the fields of a record built by a provider (incoming) with the fields
+// of the record already indexed (existing), recording the provenance of each field.
+type fieldMerger struct {
+	estv       ElasticTV
+	incoming   Provenance
+	existing   Provenance
+	provenance map[string]Provenance
+	merged     map[string]Provenance
+}
+
+func (estv ElasticTV) newFieldMerger(source string, existing Provenance, provenance map[string]Provenance) *fieldMerger {
+	return &fieldMerger{
+		estv:       estv,
+		incoming:   Provenance{Source: source, Timestamp: CurrentTimestamp()},
+		existing:   existing,
+		provenance: provenance,
+		merged:     make(map[string]Provenance),
+	}
+}
+
+func (m *fieldMerger) rule(field string) MergeRule {
+	return m.estv.MergeRules[field]
+}
+
+// origin returns the provenance of a field in the existing record, falling back to the
+// source and timestamp of the record for records indexed without provenance.
+func (m *fieldMerger) origin(field string) Provenance {
+	if origin, ok := m.provenance[field]; ok {
+		return origin
+	}
+
+	return m.existing
+}
+
+// replacesExisting returns true if the existing value of a field was supplied by the provider
+// writing the record, in which case the provider is updating its own value.
+func (m *fieldMerger) replacesExisting(field string) bool {
+	return m.origin(field).Source == m.incoming.Source
+}
+
+// prefersIncoming returns true if the value of the provider writing the record has priority
+// over the existing value of a field.
+func (m *fieldMerger) prefersIncoming(field string) bool {
+	rule := m.rule(field)
+	if rule.Policy == ReplaceMergePolicy {
+		return true
+	}
+
+	return m.estv.sourceRank(rule, m.incoming.Source) <= m.estv.sourceRank(rule, m.origin(field).Source)
+}
+
+func (m *fieldMerger) set(field string, origin Provenance) {
+	if origin.Source != "" {
+		m.merged[field] = origin
+	}
+}
+
+func mergeScalar[T cmp.Ordered](m *fieldMerger, field string, incoming, existing T) T {
+	var zero T
+
+	switch {
+	case existing == zero || m.replacesExisting(field):
+		if incoming == zero {
+			return zero
+		}
+
+		m.set(field, m.incoming)
+
+		return incoming
+	case incoming == zero:
+		m.set(field, m.origin(field))
+
+		return existing
+	}
+
+	useIncoming := m.prefersIncoming(field)
+
+	switch m.rule(field).Policy {
+	case EarliestMergePolicy:
+		useIncoming = incoming < existing
+	case LatestMergePolicy:
+		useIncoming = incoming > existing
+	}
+
+	if useIncoming {
+		m.set(field, m.incoming)
+
+		return incoming
+	}
+
+	m.set(field, m.origin(field))
+
+	return existing
+}
+
+// mergeList merges list fields. The union policies are merged by the given union function,
+// which keeps the values of all providers, while other policies keep the whole list of one
+// of the providers.
+func mergeList[T any](m *fieldMerger, field string, incoming, existing []T, union func(a, b []T) []T) []T {
+	isUnion := containsPolicy([]MergePolicy{UnionMergePolicy, MaxMergePolicy, WeightedMergePolicy},
+		m.rule(field).Policy)
+
+	switch {
+	case len(existing) == 0 || (!isUnion && m.replacesExisting(field)):
+		if len(incoming) > 0 {
+			m.set(field, m.incoming)
+		}
+
+		return incoming
+	case len(incoming) == 0:
+		m.set(field, m.origin(field))
+
+		return existing
+	case isUnion:
+		// A union is last changed by the provider writing the record
+		m.set(field, m.incoming)
+
+		return union(incoming, existing)
+	case m.prefersIncoming(field):
+		m.set(field, m.incoming)
+
+		return incoming
+	}
+
+	m.set(field, m.origin(field))
+
+	return existing
+}
+
+func (m *fieldMerger) mergeStrings(field string, incoming, existing []string) []string {
+	return mergeList(m, field, incoming, existing, func(a, b []string) []string {
+		return mergeStrings(a, b)
+	})
+}
+
+func (m *fieldMerger) mergeCredits(incoming, existing Credits) Credits {
+	list := func(c Credits) []Credits {
+		if len(c.Actor) == 0 && len(c.Director) == 0 && len(c.Other) == 0 {
+			return nil
+		}
+
+		return []Credits{c}
+	}
+
+	merged := mergeList(m, "credits", list(incoming), list(existing), func(a, b []Credits) []Credits {
+		return []Credits{{
+			Actor:    mergeStrings(a[0].Actor, b[0].Actor),
+			Director: mergeStrings(a[0].Director, b[0].Director),
+			Other:    mergeStrings(a[0].Other, b[0].Other),
+		}}
+	})
+
+	if len(merged) == 0 {
+		return Credits{}
+	}
+
+	return merged[0]
+}
+
+func (m *fieldMerger) mergeDescriptions(incoming, existing Descriptions) Descriptions {
+	return mergeList(m, "description", incoming, existing, func(a, b []Description) []Description {
+		return Descriptions(a).merge(b)
+	})
+}
+
+func (m *fieldMerger) mergeRatings(incoming, existing Ratings) Ratings {
+	rule := m.rule("rating")
+
+	// The combined rating is computed again from the ratings of the other sources
+	incoming, existing = incoming.without(CombinedRatingSource), existing.without(CombinedRatingSource)
+
+	union := func(a, b []Rating) []Rating {
+		return Ratings(a).merge(b)
+	}
+
+	ratings := Ratings(mergeList(m, "rating", incoming, existing, union))
+
+	switch rule.Policy {
+	case MaxMergePolicy:
+		ratings = ratings.withCombined(ratings.max())
+	case WeightedMergePolicy:
+		ratings = ratings.withCombined(ratings.weightedAverage(rule.Weights))
+	}
+
+	return ratings
+}
+
+// result returns the provenance of the merged record, or nil when no field has a provenance.
+func (m *fieldMerger) result() map[string]Provenance {
+	if len(m.merged) == 0 {
+		return nil
+	}
+
+	return m.merged
+}
+
+// mergeTitle merges a title built by a provider with the title already indexed, following
+// the merge rule configured for each field.
+func (estv ElasticTV) mergeTitle(title, existing Title) Title {
+	m := estv.newFieldMerger(title.Source,
+		Provenance{Source: existing.Source, Timestamp: existing.Timestamp}, existing.Provenance)
+
+	merged := Title{
+		Title:       mergeScalar(m, "title", title.Title, existing.Title),
+		Type:        title.Type,
+		Year:        mergeScalar(m, "year", title.Year, existing.Year),
+		Image:       mergeScalar(m, "image", title.Image, existing.Image),
+		Language:    mergeScalar(m, "language", title.Language, existing.Language),
+		Tagline:     mergeScalar(m, "tagline", title.Tagline, existing.Tagline),
+		IDs:         title.IDs.merge(existing.IDs),
+		Rating:      m.mergeRatings(title.Rating, existing.Rating),
+		Description: m.mergeDescriptions(title.Description, existing.Description),
+		Genre:       m.mergeStrings("genre", title.Genre, existing.Genre),
+		Country:     m.mergeStrings("country", title.Country, existing.Country),
+		Credits:     m.mergeCredits(title.Credits, existing.Credits),
+		Alias:       m.mergeStrings("alias", title.Alias, existing.Alias),
+	}
+
+	// Titles which were not chosen are kept as aliases
+	aliases := make([]string, 0)
+	for _, alias := range mergeStrings(merged.Alias, []string{title.Title, existing.Title}) {
+		if !strings.EqualFold(alias, merged.Title) {
+			aliases = append(aliases, alias)
+		}
+	}
+
+	merged.Alias = aliases
+	merged.Provenance = m.result()
+	merged.Source = firstString(merged.Provenance["title"].Source, title.Source)
+
+	return merged
+}
+
+func (estv ElasticTV) mergeEpisode(episode, existing Episode) Episode {
+	m := estv.newFieldMerger(episode.Source,
+		Provenance{Source: existing.Source, Timestamp: existing.Timestamp}, existing.Provenance)
+
+	merged := Episode{
+		Title:       mergeScalar(m, "title", episode.Title, existing.Title),
+		AirDate:     mergeScalar(m, "air_date", episode.AirDate, existing.AirDate),
+		Image:       mergeScalar(m, "image", episode.Image, existing.Image),
+		SeasonNo:    mergeScalar(m, "season", episode.SeasonNo, existing.SeasonNo),
+		EpisodeNo:   mergeScalar(m, "episode", episode.EpisodeNo, existing.EpisodeNo),
+		AbsoluteNo:  mergeScalar(m, "absolute_episode", episode.AbsoluteNo, existing.AbsoluteNo),
+		IDs:         episode.IDs.merge(existing.IDs),
+		TVShowIDs:   episode.TVShowIDs.merge(existing.TVShowIDs),
+		Rating:      m.mergeRatings(episode.Rating, existing.Rating),
+		Description: m.mergeDescriptions(episode.Description, existing.Description),
+	}
+
+	merged.Provenance = m.result()
+	merged.Source = firstString(merged.Provenance["title"].Source, episode.Source)
+
+	return merged
+}
+
+func (estv ElasticTV) mergeSeason(season, existing Season) Season {
+	m := estv.newFieldMerger(season.Source,
+		Provenance{Source: existing.Source, Timestamp: existing.Timestamp}, existing.Provenance)
+
+	merged := Season{
+		Name:         mergeScalar(m, "name", season.Name, existing.Name),
+		AirDate:      mergeScalar(m, "air_date", season.AirDate, existing.AirDate),
+		Image:        mergeScalar(m, "image", season.Image, existing.Image),
+		EpisodeCount: mergeScalar(m, "episode_count", season.EpisodeCount, existing.EpisodeCount),
+		SeasonNo:     season.SeasonNo,
+		IDs:          season.IDs.merge(existing.IDs),
+		TVShowIDs:    season.TVShowIDs.merge(existing.TVShowIDs),
+		Description:  m.mergeDescriptions(season.Description, existing.Description),
+	}
+
+	merged.Provenance = m.result()
+	merged.Source = firstString(merged.Provenance["name"].Source, season.Source)
+
+	return merged
+}
+
+// sourceRank returns the rank of a source for a field, where a lower rank has priority. The
+// providers of the merge rule come first followed by the providers in the order they were
+// added, while sources which are not a registered provider (ex the IMDb datasets importer)
+// have the lowest priority.
+func (estv ElasticTV) sourceRank(rule MergeRule, source string) int {
+	for i, provider := range rule.Providers {
+		if strings.EqualFold(provider, source) {
+			return i
+		}
+	}
+
+	for i, provider := range estv.Providers {
+		if provider.Name() == source {
+			return len(rule.Providers) + i
+		}
+	}
+
+	return len(rule.Providers) + len(estv.Providers)
+}
+
+// merge returns the IDs with any unset ID taken from other.
+func (ids IDs) merge(other IDs) IDs {
+	if ids.IMDb == "" {
+		ids.IMDb = other.IMDb
+	}
+
+	if ids.TMDb == 0 {
+		ids.TMDb = other.TMDb
+	}
+
+	if ids.TVDb == 0 {
+		ids.TVDb = other.TVDb
+	}
+
+	if ids.TVmaze == 0 {
+		ids.TVmaze = other.TVmaze
+	}
+
+	if ids.Trakt == 0 {
+		ids.Trakt = other.Trakt
+	}
+
+	if ids.TraktSlug == "" {
+		ids.TraktSlug = other.TraktSlug
+	}
+
+	if ids.Wikidata == "" {
+		ids.Wikidata = other.Wikidata
+	}
+
+	if ids.EIDR == "" {
+		ids.EIDR = other.EIDR
+	}
+
+	return ids
+}
+
+// merge returns the ratings with the ratings of any other source taken from other.
+func (r Ratings) merge(other Ratings) Ratings {
+	merged := append(Ratings{}, r...)
+
+	for _, rating := range other {
+		if merged.Get(rating.Source) == nil {
+			merged = append(merged, rating)
+		}
+	}
+
+	return merged
+}
+
+func (r Ratings) without(source string) Ratings {
+	ratings := make(Ratings, 0, len(r))
+
+	for _, rating := range r {
+		if rating.Source != source {
+			ratings = append(ratings, rating)
+		}
+	}
+
+	return ratings
+}
+
+func (r Ratings) withCombined(value float32) Ratings {
+	if value == 0 {
+		return r
+	}
+
+	return append(r, Rating{Source: CombinedRatingSource, Value: value})
+}
+
+func (r Ratings) max() float32 {
+	var value float32
+
+	for _, rating := range r {
+		value = max(value, rating.Value)
+	}
+
+	return value
+}
+
+// weightedAverage returns the average of the ratings using the weight configured for each
+// source. Sources without a configured weight have a weight of 1.
+func (r Ratings) weightedAverage(weights map[string]float32) float32 {
+	var sum, total float32
+
+	for _, rating := range r {
+		weight := float32(1)
+
+		for source, w := range weights {
+			// Configuration keys are not case sensitive
+			if strings.EqualFold(source, rating.Source) {
+				weight = w
+			}
+		}
+
+		sum += rating.Value * weight
+		total += weight
+	}
+
+	if total <= 0 {
+		return 0
+	}
+
+	return sum / total
+}
+
+// merge returns the descriptions with the descriptions of any other source or language
+// taken from other.
+func (d Descriptions) merge(other Descriptions) Descriptions {
+	merged := make(Descriptions, 0)
+
+	for _, description := range append(append(Descriptions{}, d...), other...) {
+		if description.Text == "" || merged.contains(description.Source, description.Language) {
+			continue
+		}
+
+		merged = append(merged, description)
+	}
+
+	return merged
+}
+
+func (d Descriptions) contains(source, language string) bool {
+	for _, description := range d {
+		if description.Source == source && description.Language == language {
+			return true
+		}
+	}
+
+	return false
+}
+
+func firstString(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
+
+// mergeStrings combines lists removing any duplicates while keeping their order.
+func mergeStrings(lists ...[]string) []string {
+	merged := make([]string, 0)
+
+	for _, list := range lists {
+		for _, value := range list {
+			if value == "" || containsString(merged, value) {
+				continue
+			}
+
+			merged = append(merged, value)
+		}
+	}
+
+	return merged
+}
+
+func containsString(list []string, value string) bool {
+	for _, n := range list {
+		if strings.EqualFold(n, value) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -1,35 +1,58 @@
 package elastictv
 
-import "encoding/json"
+import (
+	"bytes"
+	"encoding/json"
+)
 
 type Title struct {
-	Alias       []string    `json:"alias,omitempty"`
-	Country     []string    `json:"country,omitempty"`
-	Credits     Credits     `json:"credits"`
-	Description Description `json:"description"`
-	Genre       []string    `json:"genre,omitempty"`
-	IDs         IDs         `json:"ids"`
-	Image       string      `json:"image,omitempty"`
-	Language    string      `json:"language,omitempty"`
-	Rating      *Rating     `json:"rating,omitempty"`
-	Timestamp   string      `json:"@timestamp"`
-	Title       string      `json:"title"`
-	Type        Type        `json:"type"`
-	Year        uint16      `json:"year,omitempty"`
-	Tagline     string      `json:"tagline,omitempty"`
+	Alias       []string              `json:"alias,omitempty"`
+	Country     []string              `json:"country,omitempty"`
+	Credits     Credits               `json:"credits"`
+	Description Descriptions          `json:"description,omitempty"`
+	Genre       []string              `json:"genre,omitempty"`
+	IDs         IDs                   `json:"ids"`
+	Image       string                `json:"image,omitempty"`
+	Language    string                `json:"language,omitempty"`
+	Normalized  []string              `json:"normalized,omitempty"`
+	Provenance  map[string]Provenance `json:"provenance,omitempty"`
+	Rating      Ratings               `json:"rating,omitempty"`
+	Source      string                `json:"source,omitempty"`
+	Timestamp   string                `json:"@timestamp"`
+	Title       string                `json:"title"`
+	Type        Type                  `json:"type"`
+	Year        uint16                `json:"year,omitempty"`
+	Tagline     string                `json:"tagline,omitempty"`
 }
 
 type Episode struct {
-	AirDate     string      `json:"air_date,omitempty"`
-	Description Description `json:"description"`
-	IDs         IDs         `json:"ids"`
-	Image       string      `json:"image,omitempty"`
-	Rating      *Rating     `json:"rating,omitempty"`
-	Timestamp   string      `json:"@timestamp"`
-	Title       string      `json:"title"`
-	TVShowIDs   IDs         `json:"tvshow_ids,omitempty"`
-	EpisodeNo   uint16      `json:"episode"`
-	SeasonNo    uint16      `json:"season"`
+	AirDate     string                `json:"air_date,omitempty"`
+	Description Descriptions          `json:"description,omitempty"`
+	IDs         IDs                   `json:"ids"`
+	Image       string                `json:"image,omitempty"`
+	Provenance  map[string]Provenance `json:"provenance,omitempty"`
+	Rating      Ratings               `json:"rating,omitempty"`
+	Source      string                `json:"source,omitempty"`
+	Timestamp   string                `json:"@timestamp"`
+	Title       string                `json:"title"`
+	TVShowIDs   IDs                   `json:"tvshow_ids,omitempty"`
+	EpisodeNo   uint16                `json:"episode"`
+	SeasonNo    uint16                `json:"season"`
+	AbsoluteNo  uint16                `json:"absolute_episode,omitempty"`
+}
+
+type Season struct {
+	AirDate      string                `json:"air_date,omitempty"`
+	Description  Descriptions          `json:"description,omitempty"`
+	EpisodeCount int                   `json:"episode_count,omitempty"`
+	IDs          IDs                   `json:"ids"`
+	Image        string                `json:"image,omitempty"`
+	Name         string                `json:"name,omitempty"`
+	Provenance   map[string]Provenance `json:"provenance,omitempty"`
+	Source       string                `json:"source,omitempty"`
+	Timestamp    string                `json:"@timestamp"`
+	TVShowIDs    IDs                   `json:"tvshow_ids,omitempty"`
+	SeasonNo     uint16                `json:"season"`
 }
 
 type Credits struct {
@@ -39,24 +62,114 @@ type Credits struct {
 }
 
 type Description struct {
-	Source string `json:"source,omitempty"`
-	Text   string `json:"text,omitempty"`
+	Source   string `json:"source,omitempty"`
+	Language string `json:"language,omitempty"`
+	Text     string `json:"text,omitempty"`
+}
+
+type Descriptions []Description
+
+// UnmarshalJSON reads a list of descriptions, or the single description object of documents
+// indexed before descriptions were kept from multiple sources.
+func (d *Descriptions) UnmarshalJSON(data []byte) error {
+	if !bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		return json.Unmarshal(data, (*[]Description)(d))
+	}
+
+	description := Description{}
+	if err := json.Unmarshal(data, &description); err != nil {
+		return err
+	}
+
+	*d = nil
+	if description.Text != "" {
+		*d = Descriptions{description}
+	}
+
+	return nil
+}
+
+// Get returns the description in the given language, preferring the given sources in order.
+// An empty language matches descriptions in any language.
+func (d Descriptions) Get(language string, sources ...string) *Description {
+	for _, source := range sources {
+		for i := range d {
+			if d[i].Source == source && (language == "" || d[i].Language == language) {
+				return &d[i]
+			}
+		}
+	}
+
+	for i := range d {
+		if language == "" || d[i].Language == language {
+			return &d[i]
+		}
+	}
+
+	return nil
 }
 
 type IDs struct {
-	IMDb string `json:"imdb,omitempty"`
-	TMDb int    `json:"tmdb,omitempty"`
+	IMDb      string `json:"imdb,omitempty"`
+	TMDb      int    `json:"tmdb,omitempty"`
+	TVDb      int    `json:"tvdb,omitempty"`
+	TVmaze    int    `json:"tvmaze,omitempty"`
+	Trakt     int    `json:"trakt,omitempty"`
+	TraktSlug string `json:"trakt_slug,omitempty"`
+	Wikidata  string `json:"wikidata,omitempty"`
+	EIDR      string `json:"eidr,omitempty"`
 }
 
+// Rating holds the rating of a title from a single source on a scale of 0 to 10.
 type Rating struct {
 	Source string  `json:"source,omitempty"`
 	Value  float32 `json:"value,omitempty"`
 }
 
+type Ratings []Rating
+
+// UnmarshalJSON reads a list of ratings, or the single rating object of documents indexed
+// before ratings were kept from multiple sources.
+func (r *Ratings) UnmarshalJSON(data []byte) error {
+	if !bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		return json.Unmarshal(data, (*[]Rating)(r))
+	}
+
+	rating := Rating{}
+	if err := json.Unmarshal(data, &rating); err != nil {
+		return err
+	}
+
+	*r = nil
+	if rating.Value > 0 {
+		*r = Ratings{rating}
+	}
+
+	return nil
+}
+
+// Get returns the rating of the given source or nil if there is no rating from that source.
+func (r Ratings) Get(source string) *Rating {
+	for i := range r {
+		if r[i].Source == source {
+			return &r[i]
+		}
+	}
+
+	return nil
+}
+
 type Timestamp struct {
 	Timestamp string `json:"@timestamp"`
 }
 
+type BulkDocument struct {
+	ID       string
+	Document interface{}
+	// Delete the document with the ID instead of indexing it
+	Delete bool
+}
+
 type esResult struct {
 	Hits struct {
 		Hits []struct {
@@ -73,3 +186,27 @@ type esResult struct {
 		Reason string `json:"reason"`
 	} `json:"error"`
 }
+
+type bulkResponse struct {
+	Errors bool `json:"errors"`
+	Items  []map[string]struct {
+		ID     string `json:"_id"`
+		Status int    `json:"status"`
+		Error  struct {
+			Type   string `json:"type"`
+			Reason string `json:"reason"`
+		} `json:"error"`
+	} `json:"items"`
+}
+
+type byQueryResponse struct {
+	Deleted  int `json:"deleted"`
+	Updated  int `json:"updated"`
+	Failures []struct {
+		ID    string `json:"id"`
+		Cause struct {
+			Type   string `json:"type"`
+			Reason string `json:"reason"`
+		} `json:"cause"`
+	} `json:"failures"`
+}
@@ -0,0 +1,63 @@
+package elastictv
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestRatingsUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want Ratings
+	}{
+		{"list", `{"rating":[{"source":"TMDb","value":7.5},{"source":"IMDb","value":8}]}`,
+			Ratings{{Source: "TMDb", Value: 7.5}, {Source: "IMDb", Value: 8}}},
+		{"legacy object", `{"rating":{"source":"TMDb","value":7.5}}`, Ratings{{Source: "TMDb", Value: 7.5}}},
+		{"empty legacy object", `{"rating":{}}`, nil},
+		{"null", `{"rating":null}`, nil},
+		{"missing", `{}`, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			title := Title{}
+			if err := json.Unmarshal([]byte(tt.data), &title); err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(title.Rating, tt.want) {
+				t.Errorf("rating = %+v, want %+v", title.Rating, tt.want)
+			}
+		})
+	}
+}
+
+func TestDescriptionsUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want Descriptions
+	}{
+		{"list", `{"description":[{"source":"TMDb","language":"en","text":"Overview"}]}`,
+			Descriptions{{Source: "TMDb", Language: "en", Text: "Overview"}}},
+		{"legacy object", `{"description":{"source":"TMDb","text":"Overview"}}`,
+			Descriptions{{Source: "TMDb", Text: "Overview"}}},
+		{"empty legacy object", `{"description":{}}`, nil},
+		{"null", `{"description":null}`, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			episode := Episode{}
+			if err := json.Unmarshal([]byte(tt.data), &episode); err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(episode.Description, tt.want) {
+				t.Errorf("description = %+v, want %+v", episode.Description, tt.want)
+			}
+		})
+	}
+}
@@ -15,12 +15,26 @@ type ElasticTV struct {
 	Providers   []SearchableProvider
 	UpdateAfter time.Time
 	Index       index
+	// Merge rules of fields written by several providers, keyed by field name
+	MergeRules      map[string]MergeRule
+	providerOptions map[string]providerOptions
+	blocklist       *cache[BlocklistEntry]
+	overrides       *cache[Override]
+	// Number of days after which cached records are updated, as configured by
+	// elastictv.update_after_days
+	updateAfterDays int
 }
 
 type index struct {
 	Title   string
 	Episode string
-	Search  string
+	// Seasons are only cached when the season index is configured
+	Season string
+	Search string
+	// Overrides are only consulted when the override index is configured
+	Override string
+	// Titles are only blocked when the blocklist index is configured
+	Blocklist string
 }
 
 func New() (*ElasticTV, error) {
@@ -40,14 +54,32 @@ func New() (*ElasticTV, error) {
 		updateAfterDays = defaultUpdateAfterDays
 	}
 
+	configuredRules := make(map[string]MergeRule)
+	if err := viper.UnmarshalKey("elastictv.merge", &configuredRules); err != nil {
+		return nil, fmt.Errorf("unable to parse merge rules: %w", err)
+	}
+
+	mergeRules, err := newMergeRules(configuredRules)
+	if err != nil {
+		return nil, fmt.Errorf("unable to init elastictv: %w", err)
+	}
+
 	return &ElasticTV{
-		Client:      client,
-		Providers:   make([]SearchableProvider, 0),
-		UpdateAfter: time.Now().AddDate(0, 0, -updateAfterDays),
+		Client:          client,
+		Providers:       make([]SearchableProvider, 0),
+		providerOptions: make(map[string]providerOptions),
+		blocklist:       &cache[BlocklistEntry]{},
+		overrides:       &cache[Override]{},
+		UpdateAfter:     time.Now().AddDate(0, 0, -updateAfterDays),
+		updateAfterDays: updateAfterDays,
 		Index: index{
-			Title:   viper.GetString("elastictv.elasticsearch.index.title"),
-			Episode: viper.GetString("elastictv.elasticsearch.index.episode"),
-			Search:  viper.GetString("elastictv.elasticsearch.index.search"),
+			Title:     viper.GetString("elastictv.elasticsearch.index.title"),
+			Episode:   viper.GetString("elastictv.elasticsearch.index.episode"),
+			Season:    viper.GetString("elastictv.elasticsearch.index.season"),
+			Search:    viper.GetString("elastictv.elasticsearch.index.search"),
+			Override:  viper.GetString("elastictv.elasticsearch.index.override"),
+			Blocklist: viper.GetString("elastictv.elasticsearch.index.blocklist"),
 		},
+		MergeRules: mergeRules,
 	}, nil
 }
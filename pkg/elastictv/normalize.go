@@ -0,0 +1,171 @@
+package elastictv
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/spf13/viper"
+)
+
+// defaultArticleLanguages are the languages of the articles removed from the start of titles
+// unless elastictv.title.article_languages is set. German is excluded since its articles "die"
+// and "das" start English titles such as Die Hard.
+var defaultArticleLanguages = []string{"en", "fr", "es", "it"}
+
+var (
+	// titleArticles are the articles of each language which are removed from the start of titles
+	titleArticles = map[string][]string{
+		"en": {"the", "a", "an"},
+		"fr": {"le", "la", "les", "l", "un", "une"},
+		"es": {"el", "la", "los", "las", "un", "una", "uno"},
+		"it": {"il", "lo", "la", "l", "gli", "le", "un", "una", "uno"},
+		"de": {"der", "die", "das", "ein", "eine"},
+	}
+
+	numberWords = []string{
+		"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine", "ten",
+		"eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen", "seventeen", "eighteen",
+		"nineteen", "twenty",
+	}
+
+	romanNumerals = map[rune]int{'i': 1, 'v': 5, 'x': 10, 'l': 50, 'c': 100}
+
+	diacritics = strings.NewReplacer(
+		"à", "a", "á", "a", "â", "a", "ã", "a", "ä", "a", "å", "a", "ā", "a", "ą", "a", "æ", "ae",
+		"ç", "c", "ć", "c", "č", "c",
+		"ď", "d", "đ", "d", "ð", "d",
+		"è", "e", "é", "e", "ê", "e", "ë", "e", "ē", "e", "ę", "e", "ě", "e",
+		"ğ", "g",
+		"ì", "i", "í", "i", "î", "i", "ï", "i", "ī", "i", "ı", "i",
+		"ł", "l",
+		"ñ", "n", "ń", "n", "ň", "n",
+		"ò", "o", "ó", "o", "ô", "o", "õ", "o", "ö", "o", "ø", "o", "ō", "o", "ő", "o", "œ", "oe",
+		"ř", "r",
+		"ś", "s", "š", "s", "ş", "s", "ß", "ss",
+		"ť", "t", "ţ", "t", "þ", "th",
+		"ù", "u", "ú", "u", "û", "u", "ü", "u", "ū", "u", "ů", "u", "ű", "u",
+		"ý", "y", "ÿ", "y",
+		"ź", "z", "ż", "z", "ž", "z",
+		"&", " and ",
+	)
+)
+
+// NormalizeTitle returns the form of a title used to match titles which are written differently,
+// such as "Star Wars: Episode IV" and "Star Wars Episode 4". Titles are lowercased and stripped
+// of diacritics, punctuation and a leading article, ampersands are replaced by "and" while roman
+// numerals and number words are replaced by digits. The title_analyzer in configs/title.json
+// applies the same rules to titles matched by Elasticsearch, so its
+// title_leading_articles_filter has to list the articles of elastictv.title.article_languages.
+func NormalizeTitle(title string) string {
+	title = diacritics.Replace(strings.ToLower(title))
+
+	words := strings.FieldsFunc(title, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+
+	// Only a leading article is removed, so titles such as Live and Let Die keep their words
+	if len(words) > 1 && isLeadingArticle(words[0]) {
+		words = words[1:]
+	}
+
+	for i, word := range words {
+		words[i] = normalizeNumber(word)
+	}
+
+	return strings.Join(words, " ")
+}
+
+func isLeadingArticle(word string) bool {
+	languages := defaultArticleLanguages
+	if viper.IsSet("elastictv.title.article_languages") {
+		languages = viper.GetStringSlice("elastictv.title.article_languages")
+	}
+
+	for _, language := range languages {
+		if containsString(titleArticles[language], word) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NormalizedTitles returns the normalized title and aliases of a title.
+func (t Title) NormalizedTitles() []string {
+	normalized := make([]string, 0, len(t.Alias)+1)
+
+	for _, title := range append([]string{t.Title}, t.Alias...) {
+		if title = NormalizeTitle(title); title != "" && !containsString(normalized, title) {
+			normalized = append(normalized, title)
+		}
+	}
+
+	return normalized
+}
+
+// normalizeNumber replaces number words and roman numerals from 2 to 39 by digits. Single letter
+// numerals are kept since they are more often a word or a letter (ex Malcolm X or V for Vendetta).
+func normalizeNumber(word string) string {
+	for number, numberWord := range numberWords {
+		if word == numberWord {
+			return strconv.Itoa(number)
+		}
+	}
+
+	if len(word) < 2 {
+		return word
+	}
+
+	if number := parseRomanNumeral(word); number > 1 && number < 40 {
+		return strconv.Itoa(number)
+	}
+
+	return word
+}
+
+// parseRomanNumeral returns the value of a roman numeral in its canonical form, or 0 if the word
+// is not one.
+func parseRomanNumeral(word string) int {
+	number := 0
+
+	for i, r := range word {
+		value, ok := romanNumerals[r]
+		if !ok {
+			return 0
+		}
+
+		if i+1 < len(word) && value < romanNumerals[rune(word[i+1])] {
+			number -= value
+		} else {
+			number += value
+		}
+	}
+
+	// Words such as "mix" or "civil" are not valid numerals when written back
+	if number <= 0 || formatRomanNumeral(number) != word {
+		return 0
+	}
+
+	return number
+}
+
+func formatRomanNumeral(number int) string {
+	numerals := []struct {
+		value   int
+		numeral string
+	}{
+		{100, "c"}, {90, "xc"}, {50, "l"}, {40, "xl"}, {10, "x"}, {9, "ix"}, {5, "v"}, {4, "iv"}, {1, "i"},
+	}
+
+	var builder strings.Builder
+
+	for _, n := range numerals {
+		for number >= n.value {
+			builder.WriteString(n.numeral)
+			number -= n.value
+		}
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,47 @@
+package elastictv
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestNormalizeTitle(t *testing.T) {
+	tests := []struct {
+		title string
+		want  string
+	}{
+		{"The Matrix", "matrix"},
+		{"Live and Let Die", "live and let die"},
+		{"A Man and a Woman", "man and a woman"},
+		{"L'Avventura", "avventura"},
+		{"The", "the"},
+		{"Die Hard", "die hard"},
+		{"Malcolm X", "malcolm x"},
+		{"V for Vendetta", "v for vendetta"},
+		{"Star Wars: Episode IV - A New Hope", "star wars episode 4 a new hope"},
+		{"Rocky II", "rocky 2"},
+		{"Ocean's Eleven", "ocean s 11"},
+		{"Tom & Jerry", "tom and jerry"},
+		{"Amélie", "amelie"},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeTitle(tt.title); got != tt.want {
+			t.Errorf("NormalizeTitle(%q) = %q, want %q", tt.title, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizeTitleArticleLanguages(t *testing.T) {
+	viper.Set("elastictv.title.article_languages", []string{"de"})
+	t.Cleanup(viper.Reset)
+
+	if got := NormalizeTitle("Das Boot"); got != "boot" {
+		t.Errorf("NormalizeTitle(Das Boot) = %q, want boot", got)
+	}
+
+	if got := NormalizeTitle("The Matrix"); got != "the matrix" {
+		t.Errorf("NormalizeTitle(The Matrix) = %q, want the matrix", got)
+	}
+}
@@ -0,0 +1,68 @@
+package omdb
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	defaultBaseURL = "https://www.omdbapi.com"
+	requestTimeout = 30 * time.Second
+	notFoundError  = "not found!"
+)
+
+var errNotFound = errors.New("not found")
+
+type client struct {
+	baseURL    string
+	apiKey     string
+	httpClient *http.Client
+}
+
+func newClient(baseURL, apiKey string) client {
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
+
+	return client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		apiKey:     apiKey,
+		httpClient: &http.Client{Timeout: requestTimeout},
+	}
+}
+
+// get requests a title from OMDb. OMDb replies with status 200 even when a title is not
+// found so the Response and Error fields of the reply are checked instead.
+func (c client) get(params url.Values) (*title, error) {
+	params.Set("apikey", c.apiKey)
+
+	response, err := c.httpClient.Get(c.baseURL + "/?" + params.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("error requesting OMDb: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("request to OMDb returned status %s", response.Status)
+	}
+
+	result := &title{}
+	if err := json.NewDecoder(response.Body).Decode(result); err != nil {
+		return nil, fmt.Errorf("error parsing reply from OMDb: %w", err)
+	}
+
+	if result.Response != "True" {
+		if strings.HasSuffix(strings.ToLower(result.Error), notFoundError) {
+			return nil, errNotFound
+		}
+
+		return nil, fmt.Errorf("OMDb returned error: %s", result.Error)
+	}
+
+	return result, nil
+}
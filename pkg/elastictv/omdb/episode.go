@@ -0,0 +1,98 @@
+package omdb
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/shaunschembri/elastictv/pkg/elastictv"
+)
+
+const (
+	releasedFormat = "02 Jan 2006"
+	airDateFormat  = "2006-01-02"
+)
+
+func (o OMDb) SearchEpisode(searchItem elastictv.SearchItem) error {
+	params := url.Values{
+		"type": {omdbTypes[elastictv.EpisodeType]},
+		"plot": {"full"},
+	}
+
+	tvshowTMDbID := 0
+
+	switch {
+	case searchItem.Attribute == elastictv.IMDbIDSearchAttribute:
+		params.Set("i", fmt.Sprintf("%v", searchItem.Query))
+	case searchItem.Attribute == elastictv.TMDbIDSearchAttribute && searchItem.SeasonNo > 0 && searchItem.EpisodeNo > 0:
+		imdbID, err := o.getIMDbID(elastictv.TvShowType, searchItem.Query)
+		if err != nil || imdbID == "" {
+			return err
+		}
+
+		tvshowTMDbID, _ = searchItem.Query.(int)
+
+		params.Set("i", imdbID)
+		params.Set("Season", strconv.Itoa(int(searchItem.SeasonNo)))
+		params.Set("Episode", strconv.Itoa(int(searchItem.EpisodeNo)))
+	default:
+		return elastictv.NewNotSupportedError(o.Name(), searchItem)
+	}
+
+	log.Printf("%s: Searching for episode [ %s ]", o.Name(), searchItem)
+
+	details, err := o.client.get(params)
+	if errors.Is(err, errNotFound) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("%s: error searching for episode [ %s ]: %w", o.Name(), searchItem, err)
+	}
+
+	return o.indexEpisode(details, tvshowTMDbID)
+}
+
+func (o OMDb) indexEpisode(details *title, tvshowTMDbID int) error {
+	episode := elastictv.Episode{
+		Title:   details.Title,
+		AirDate: o.getAirDate(details.Released),
+		IDs: elastictv.IDs{
+			IMDb: details.IMDbID,
+		},
+		TVShowIDs: elastictv.IDs{
+			IMDb: details.SeriesID,
+			TMDb: tvshowTMDbID,
+		},
+		SeasonNo:    o.getNumber(details.Season),
+		EpisodeNo:   o.getNumber(details.Episode),
+		Image:       o.getValue(details.Poster),
+		Rating:      o.getRatings(details),
+		Description: o.getDescription(details.Plot),
+		Source:      o.Name(),
+	}
+
+	if err := o.estv.UpsertEpisode(episode); err != nil {
+		return fmt.Errorf("%s: error indexing episode [ %s ]: %w", o.Name(), details.Title, err)
+	}
+
+	return nil
+}
+
+func (o OMDb) getNumber(value string) uint16 {
+	number, _ := strconv.ParseUint(value, 10, 16)
+
+	return uint16(number)
+}
+
+func (o OMDb) getAirDate(released string) string {
+	date, err := time.Parse(releasedFormat, released)
+	if err != nil {
+		return ""
+	}
+
+	return date.Format(airDateFormat)
+}
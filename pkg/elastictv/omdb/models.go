@@ -0,0 +1,26 @@
+package omdb
+
+type title struct {
+	Title    string `json:"Title"`
+	Year     string `json:"Year"`
+	Released string `json:"Released"`
+	Genre    string `json:"Genre"`
+	Director string `json:"Director"`
+	Writer   string `json:"Writer"`
+	Actors   string `json:"Actors"`
+	Plot     string `json:"Plot"`
+	Language string `json:"Language"`
+	Country  string `json:"Country"`
+	Poster   string `json:"Poster"`
+	Ratings  []struct {
+		Source string `json:"Source"`
+		Value  string `json:"Value"`
+	} `json:"Ratings"`
+	IMDbID   string `json:"imdbID"`
+	SeriesID string `json:"seriesID"`
+	Season   string `json:"Season"`
+	Episode  string `json:"Episode"`
+	Type     string `json:"Type"`
+	Response string `json:"Response"`
+	Error    string `json:"Error"`
+}
@@ -0,0 +1,190 @@
+package omdb
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/elastic/go-elasticsearch/v8"
+
+	"github.com/shaunschembri/elastictv/pkg/elastictv"
+)
+
+const testAPIKey = "test-api-key"
+
+// newTestServer serves the recorded OMDb replies in testdata by IMDb ID. Like OMDb it replies with
+// status 200 and "Response": "False" for titles it does not know.
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	fixtures := map[string]string{
+		"tt0903747": "testdata/series.json",
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fixture := "testdata/invalid_key.json"
+
+		if r.URL.Query().Get("apikey") == testAPIKey {
+			fixture = "testdata/not_found.json"
+			if path, ok := fixtures[r.URL.Query().Get("i")]; ok {
+				fixture = path
+			}
+		}
+
+		data, err := os.ReadFile(fixture)
+		if err != nil {
+			t.Error(err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(data)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// newTestElasticTV returns an ElasticTV backed by an elasticsearch server which finds the title
+// with TMDb ID 1396 and nothing else.
+func newTestElasticTV(t *testing.T) *elastictv.ElasticTV {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+
+		body, _ := io.ReadAll(r.Body)
+		if r.URL.Path != "/titles/_search" || !strings.Contains(string(body), `"ids.tmdb":1396`) {
+			w.Write([]byte(`{"hits":{"total":{"value":0},"hits":[]}}`))
+
+			return
+		}
+
+		data, err := os.ReadFile("testdata/search_titles.json")
+		if err != nil {
+			t.Error(err)
+		}
+
+		w.Write(data)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := elasticsearch.NewClient(elasticsearch.Config{Addresses: []string{server.URL}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	estv := &elastictv.ElasticTV{Client: client}
+	estv.Index.Title = "titles"
+
+	return estv
+}
+
+func newTestOMDb(t *testing.T) OMDb {
+	t.Helper()
+
+	return OMDb{
+		estv:   newTestElasticTV(t),
+		client: newClient(newTestServer(t).URL, testAPIKey),
+	}
+}
+
+func TestParseRating(t *testing.T) {
+	tests := []struct {
+		rating string
+		want   float32
+		ok     bool
+	}{
+		{"87%", 8.7, true},
+		{"74/100", 7.4, true},
+		{"8.1/10", 8.1, true},
+		{"N/A", 0, false},
+		{"5/0", 0, false},
+	}
+
+	for _, tt := range tests {
+		value, ok := OMDb{}.parseRating(tt.rating)
+		if ok != tt.ok || (ok && (value < tt.want-0.001 || value > tt.want+0.001)) {
+			t.Errorf("parseRating(%q) = %v, %v, want %v, %v", tt.rating, value, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestClientGet(t *testing.T) {
+	o := newTestOMDb(t)
+
+	details, err := o.client.get(map[string][]string{"i": {"tt0903747"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := elastictv.Ratings{
+		{Source: "IMDb", Value: 9.5},
+		{Source: "Rotten Tomatoes", Value: 9.6},
+		{Source: "Metacritic", Value: 8.7},
+	}
+
+	ratings := o.getRatings(details)
+	if len(ratings) != len(want) {
+		t.Fatalf("getRatings() = %+v, want %+v", ratings, want)
+	}
+
+	for n, rating := range ratings {
+		if rating.Source != want[n].Source || rating.Value < want[n].Value-0.001 || rating.Value > want[n].Value+0.001 {
+			t.Errorf("rating = %+v, want %+v", rating, want[n])
+		}
+	}
+
+	// OMDb replies with status 200 and "Response": "False" when a title is not found
+	if _, err := o.client.get(map[string][]string{"i": {"tt0000001"}}); !errors.Is(err, errNotFound) {
+		t.Errorf("get() of unknown title error = %v, want %v", err, errNotFound)
+	}
+
+	// Other errors are not reported as not found
+	client := newClient(newTestServer(t).URL, "invalid")
+	if _, err := client.get(map[string][]string{"i": {"tt0903747"}}); err == nil || errors.Is(err, errNotFound) {
+		t.Errorf("get() with invalid api key error = %v, want an error", err)
+	}
+}
+
+func TestNotFound(t *testing.T) {
+	item := elastictv.NewSearchItem(elastictv.MovieType, elastictv.IMDbIDSearchAttribute, "tt0000001")
+
+	if err := newTestOMDb(t).SearchMovies(item); err != nil {
+		t.Errorf("SearchMovies() of unknown title = %v, want no error", err)
+	}
+}
+
+func TestGetIMDbID(t *testing.T) {
+	o := newTestOMDb(t)
+
+	tests := []struct {
+		name    string
+		tmdbID  any
+		want    string
+		wantErr bool
+	}{
+		{"indexed title", 1396, "tt0903747", false},
+		{"unknown title", 1, "", false},
+		{"invalid id", "1396", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			imdbID, err := o.getIMDbID(elastictv.TvShowType, tt.tmdbID)
+			if (err != nil) != tt.wantErr || imdbID != tt.want {
+				t.Errorf("getIMDbID(%v) = %q, %v, want %q", tt.tmdbID, imdbID, err, tt.want)
+			}
+		})
+	}
+
+	// Titles which are not indexed with an IMDb ID are not requested from OMDb
+	item := elastictv.NewSearchItem(elastictv.TvShowType, elastictv.TMDbIDSearchAttribute, 1)
+	if err := o.SearchTvShows(item); err != nil {
+		t.Errorf("SearchTvShows() of title without IMDb ID = %v, want no error", err)
+	}
+}
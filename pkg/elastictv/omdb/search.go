@@ -0,0 +1,274 @@
+package omdb
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/viper"
+
+	"github.com/shaunschembri/elastictv/pkg/elastictv"
+)
+
+const (
+	// OMDb only lists plots in English
+	descriptionLanguage = "en"
+	notAvailable        = "N/A"
+	maxOtherCredits     = 5
+	ratingScale         = 10
+)
+
+var (
+	omdbTypes = map[elastictv.Type]string{
+		elastictv.MovieType:   "movie",
+		elastictv.TvShowType:  "series",
+		elastictv.EpisodeType: "episode",
+	}
+	ratingSources = map[string]string{
+		"Internet Movie Database": "IMDb",
+	}
+)
+
+// OMDb contributes IMDb, Rotten Tomatoes and Metacritic ratings and plot text to titles.
+type OMDb struct {
+	estv   *elastictv.ElasticTV
+	client client
+}
+
+func (o OMDb) Name() string {
+	return "OMDb"
+}
+
+func (o OMDb) Init(estv *elastictv.ElasticTV) (elastictv.SearchableProvider, error) {
+	apiKey := viper.GetString("elastictv.provider.omdb.api_key")
+	if apiKey == "" {
+		return nil, errors.New("omdb api key is not set")
+	}
+
+	o.client = newClient(viper.GetString("elastictv.provider.omdb.base_url"), apiKey)
+	o.estv = estv
+
+	return o, nil
+}
+
+func (o OMDb) Capabilities() elastictv.Capabilities {
+	return elastictv.Capabilities{
+		{Type: elastictv.MovieType, Attributes: []elastictv.SearchAttribute{
+			elastictv.TitleSearchAttribute, elastictv.IMDbIDSearchAttribute, elastictv.TMDbIDSearchAttribute,
+		}},
+		{Type: elastictv.TvShowType, Attributes: []elastictv.SearchAttribute{
+			elastictv.TitleSearchAttribute, elastictv.IMDbIDSearchAttribute, elastictv.TMDbIDSearchAttribute,
+		}},
+		{Type: elastictv.EpisodeType, Attributes: []elastictv.SearchAttribute{
+			elastictv.IMDbIDSearchAttribute, elastictv.TMDbIDSearchAttribute,
+		}},
+	}
+}
+
+func (o OMDb) SearchMovies(params elastictv.SearchItem) error {
+	return o.searchTitle(elastictv.MovieType, params)
+}
+
+func (o OMDb) SearchTvShows(params elastictv.SearchItem) error {
+	return o.searchTitle(elastictv.TvShowType, params)
+}
+
+func (o OMDb) searchTitle(docType elastictv.Type, searchItem elastictv.SearchItem) error {
+	params := url.Values{
+		"type": {omdbTypes[docType]},
+		"plot": {"full"},
+	}
+
+	switch searchItem.Attribute {
+	case elastictv.TitleSearchAttribute:
+		params.Set("t", fmt.Sprintf("%v", searchItem.Query))
+
+		if searchItem.Year > 0 {
+			params.Set("y", strconv.Itoa(int(searchItem.Year)))
+		}
+	case elastictv.IMDbIDSearchAttribute:
+		params.Set("i", fmt.Sprintf("%v", searchItem.Query))
+	case elastictv.TMDbIDSearchAttribute:
+		imdbID, err := o.getIMDbID(docType, searchItem.Query)
+		if err != nil || imdbID == "" {
+			return err
+		}
+
+		params.Set("i", imdbID)
+	default:
+		return elastictv.NewNotSupportedError(o.Name(), searchItem)
+	}
+
+	log.Printf("%s: Searching for %s [ %s ]", o.Name(), docType, searchItem)
+
+	details, err := o.client.get(params)
+	if errors.Is(err, errNotFound) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("%s: error searching for %s [ %s ]: %w", o.Name(), docType, searchItem, err)
+	}
+
+	return o.indexTitle(docType, details)
+}
+
+// getIMDbID returns the IMDb ID of a title which is indexed with the given TMDb ID.
+func (o OMDb) getIMDbID(docType elastictv.Type, tmdbID any) (string, error) {
+	id, ok := tmdbID.(int)
+	if !ok {
+		return "", fmt.Errorf("%s: cannot convert query item [ %s ] to TMDb ID", o.Name(), tmdbID)
+	}
+
+	title := elastictv.Title{}
+	query := elastictv.NewQuery().WithTMDbID(id).WithType(docType)
+
+	if _, err := o.estv.GetRecord(query, o.estv.Index.Title, &title); err != nil {
+		return "", fmt.Errorf("%s: error getting title with TMDb ID %d: %w", o.Name(), id, err)
+	}
+
+	return title.IDs.IMDb, nil
+}
+
+func (o OMDb) indexTitle(docType elastictv.Type, details *title) error {
+	log.Printf("%s: Got details for %s [ %s | Year: %s ]", o.Name(), docType, details.Title, details.Year)
+
+	title := elastictv.Title{
+		Title: details.Title,
+		IDs: elastictv.IDs{
+			IMDb: details.IMDbID,
+		},
+		Year:        o.getYear(details.Year),
+		Genre:       o.getList(details.Genre),
+		Country:     o.getList(details.Country),
+		Language:    o.getLanguage(details.Language),
+		Image:       o.getValue(details.Poster),
+		Credits:     o.getCredits(details),
+		Rating:      o.getRatings(details),
+		Description: o.getDescription(details.Plot),
+		Type:        docType,
+		Source:      o.Name(),
+	}
+
+	if err := o.estv.UpsertTitle(title); err != nil {
+		return fmt.Errorf("error indexing %s: %w", docType, err)
+	}
+
+	return nil
+}
+
+func (o OMDb) getValue(value string) string {
+	if value == notAvailable {
+		return ""
+	}
+
+	return strings.TrimSpace(value)
+}
+
+func (o OMDb) getList(value string) []string {
+	list := make([]string, 0)
+
+	for _, item := range strings.Split(o.getValue(value), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+
+	return list
+}
+
+func (o OMDb) getYear(year string) uint16 {
+	if len(year) < 4 {
+		return 0
+	}
+
+	value, _ := strconv.Atoi(year[:4])
+
+	return uint16(value)
+}
+
+func (o OMDb) getLanguage(languages string) string {
+	list := o.getList(languages)
+	if len(list) == 0 {
+		return ""
+	}
+
+	return list[0]
+}
+
+func (o OMDb) getCredits(details *title) elastictv.Credits {
+	credits := elastictv.Credits{
+		Director: o.getList(details.Director),
+		Actor:    o.getList(details.Actors),
+	}
+
+	for _, writer := range o.getList(details.Writer) {
+		if len(credits.Other) < maxOtherCredits {
+			credits.Other = append(credits.Other, writer)
+		}
+	}
+
+	return credits
+}
+
+func (o OMDb) getDescription(plot string) elastictv.Descriptions {
+	text := o.getValue(plot)
+	if text == "" {
+		return nil
+	}
+
+	return elastictv.Descriptions{{
+		Text:     text,
+		Language: descriptionLanguage,
+		Source:   o.Name(),
+	}}
+}
+
+// getRatings converts the ratings returned by OMDb (ex 8.1/10, 87% or 74/100) to a scale of 0 to 10.
+func (o OMDb) getRatings(details *title) elastictv.Ratings {
+	ratings := make(elastictv.Ratings, 0)
+
+	for _, rating := range details.Ratings {
+		value, ok := o.parseRating(rating.Value)
+		if !ok {
+			continue
+		}
+
+		source := rating.Source
+		if name, ok := ratingSources[source]; ok {
+			source = name
+		}
+
+		ratings = append(ratings, elastictv.Rating{
+			Source: source,
+			Value:  value,
+		})
+	}
+
+	return ratings
+}
+
+func (o OMDb) parseRating(rating string) (float32, bool) {
+	value, scale := rating, "10"
+
+	if percentage, ok := strings.CutSuffix(rating, "%"); ok {
+		value, scale = percentage, "100"
+	} else if parts := strings.SplitN(rating, "/", 2); len(parts) == 2 {
+		value, scale = parts[0], parts[1]
+	}
+
+	number, err := strconv.ParseFloat(strings.TrimSpace(value), 32)
+	if err != nil {
+		return 0, false
+	}
+
+	maxValue, err := strconv.ParseFloat(strings.TrimSpace(scale), 32)
+	if err != nil || maxValue == 0 {
+		return 0, false
+	}
+
+	return float32(number / maxValue * ratingScale), true
+}
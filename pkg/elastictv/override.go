@@ -0,0 +1,301 @@
+package elastictv
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"regexp"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+// maxOverrides is the maximum number of overrides, which are all read when the overrides with
+// a filename pattern are loaded.
+const maxOverrides = 10000
+
+var errNoOverrideIndex = errors.New("override index is not configured")
+
+// Override pins the lookups of a title, or of files matching a filename pattern, to a movie or
+// tv show which the lookups get wrong.
+type Override struct {
+	ID   string `json:"-"`
+	Type Type   `json:"type"`
+	// Title is matched by its normalized form in lookups of Year, or of any year if Year is 0
+	Title      string `json:"title,omitempty"`
+	Normalized string `json:"normalized,omitempty"`
+	Year       uint16 `json:"year,omitempty"`
+	// Pattern is a regular expression matched against the filename of lookups
+	Pattern string `json:"pattern,omitempty"`
+	// IDs of the title which lookups return, which needs the TMDb or IMDb ID
+	IDs       IDs    `json:"ids"`
+	Timestamp string `json:"@timestamp"`
+
+	pattern *regexp.Regexp
+}
+
+func (o Override) validate() error {
+	if o.Type != MovieType && o.Type != TvShowType {
+		return fmt.Errorf("override of [ %s ] is not of a movie or tv show", o.Title)
+	}
+
+	if o.Normalized == "" && o.Pattern == "" {
+		return fmt.Errorf("override has neither a title nor a filename pattern")
+	}
+
+	if _, err := regexp.Compile(o.Pattern); err != nil {
+		return fmt.Errorf("invalid filename pattern of override [ %s ]: %w", o.Pattern, err)
+	}
+
+	if o.IDs.TMDb == 0 && o.IDs.IMDb == "" {
+		return fmt.Errorf("override of [ %s%s ] has neither a TMDb nor an IMDb ID", o.Title, o.Pattern)
+	}
+
+	return nil
+}
+
+// key identifies overrides of the same lookup, so adding an override replaces any override of
+// the same title, year and pattern.
+func (o Override) key() string {
+	sum := sha1.Sum([]byte(fmt.Sprintf("%s|%s|%d|%s", o.Type, o.Normalized, o.Year, o.Pattern)))
+
+	return hex.EncodeToString(sum[:])
+}
+
+func (o Override) matchesFilename(filename string) bool {
+	return o.pattern != nil && filename != "" && o.pattern.MatchString(filename)
+}
+
+// AddOverride adds an override, replacing any override of the same lookup.
+func (estv ElasticTV) AddOverride(override Override) (Override, error) {
+	if estv.Index.Override == "" {
+		return override, errNoOverrideIndex
+	}
+
+	override.Normalized = NormalizeTitle(override.Title)
+	if err := override.validate(); err != nil {
+		return override, err
+	}
+
+	override.ID = override.key()
+	override.Timestamp = CurrentTimestamp()
+
+	if err := estv.index(estv.Index.Override, override.ID, override); err != nil {
+		return override, err
+	}
+
+	estv.resetOverrides()
+
+	return override, estv.RefreshIndices(estv.Index.Override)
+}
+
+// Overrides lists all overrides.
+func (estv ElasticTV) Overrides() ([]Override, error) {
+	if estv.Index.Override == "" {
+		return nil, errNoOverrideIndex
+	}
+
+	return estv.searchOverrides(NewQuery().SortByTimestamp(true))
+}
+
+func (estv ElasticTV) searchOverrides(query *Query) ([]Override, error) {
+	result, err := estv.search(query, estv.Index.Override, maxOverrides)
+	if err != nil {
+		return nil, err
+	}
+
+	overrides := make([]Override, 0, len(result.Hits.Hits))
+
+	for _, hit := range result.Hits.Hits {
+		override := Override{ID: hit.ID}
+		if err := json.Unmarshal(hit.Source, &override); err != nil {
+			return nil, fmt.Errorf("error parsing source: %w", err)
+		}
+
+		overrides = append(overrides, override)
+	}
+
+	return overrides, nil
+}
+
+// DeleteOverride deletes the override with the given ID.
+func (estv ElasticTV) DeleteOverride(id string) error {
+	if estv.Index.Override == "" {
+		return errNoOverrideIndex
+	}
+
+	if err := estv.BulkIndex(estv.Index.Override, []BulkDocument{{ID: id, Delete: true}}); err != nil {
+		return err
+	}
+
+	estv.resetOverrides()
+
+	return estv.RefreshIndices(estv.Index.Override)
+}
+
+// ExportOverrides writes all overrides as JSON, one override per line.
+func (estv ElasticTV) ExportOverrides(w io.Writer) error {
+	overrides, err := estv.Overrides()
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(w)
+	for _, override := range overrides {
+		if err := encoder.Encode(override); err != nil {
+			return fmt.Errorf("error encoding override: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ImportOverrides adds the overrides written by ExportOverrides, returning how many were added.
+func (estv ElasticTV) ImportOverrides(r io.Reader) (int, error) {
+	var importErrors *multierror.Error
+
+	imported := 0
+	decoder := json.NewDecoder(r)
+
+	for decoder.More() {
+		override := Override{}
+		if err := decoder.Decode(&override); err != nil {
+			return imported, multierror.Append(importErrors, fmt.Errorf("error parsing override: %w", err))
+		}
+
+		if _, err := estv.AddOverride(override); err != nil {
+			importErrors = multierror.Append(importErrors, err)
+
+			continue
+		}
+
+		imported++
+	}
+
+	return imported, importErrors.ErrorOrNil()
+}
+
+// findOverride returns the override of a lookup. Overrides matching the filename come first,
+// then those of the title and year and last those of the title in any year.
+func (estv ElasticTV) findOverride(docType Type, params LookupCommonParams, year uint16) (*Override, error) {
+	if estv.Index.Override == "" {
+		return nil, nil
+	}
+
+	patterns, err := estv.getPatternOverrides()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, override := range patterns {
+		if override.Type == docType && override.matchesFilename(params.Filename) {
+			return &patterns[i], nil
+		}
+	}
+
+	titles := make([]string, 0, len(params.Title))
+	for _, title := range params.Title {
+		if normalized := NormalizeTitle(title); normalized != "" {
+			titles = append(titles, normalized)
+		}
+	}
+
+	if len(titles) == 0 {
+		return nil, nil
+	}
+
+	overrides, err := estv.searchOverrides(NewQuery().
+		WithType(docType).
+		WithNormalizedTitles(titles...).
+		SortByTimestamp(true))
+	if err != nil {
+		return nil, err
+	}
+
+	var titleOverride *Override
+
+	for i, override := range overrides {
+		switch {
+		case override.Year > 0 && override.Year == year:
+			titleOverride = &overrides[i]
+		case override.Year == 0 && titleOverride == nil:
+			titleOverride = &overrides[i]
+		}
+	}
+
+	return titleOverride, nil
+}
+
+// getPatternOverrides returns the overrides with a filename pattern, with their patterns
+// compiled. The overrides are cached by ElasticTV created by New.
+func (estv ElasticTV) getPatternOverrides() ([]Override, error) {
+	load := func() ([]Override, error) {
+		overrides, err := estv.Overrides()
+		if err != nil {
+			return nil, err
+		}
+
+		patterns := make([]Override, 0)
+
+		for _, override := range overrides {
+			if override.Pattern == "" {
+				continue
+			}
+
+			if override.pattern, err = regexp.Compile(override.Pattern); err != nil {
+				log.Printf("Invalid filename pattern of override [ %s ] is skipped: %s", override.ID, err)
+
+				continue
+			}
+
+			patterns = append(patterns, override)
+		}
+
+		return patterns, nil
+	}
+
+	if estv.overrides == nil {
+		return load()
+	}
+
+	return estv.overrides.get(load)
+}
+
+func (estv ElasticTV) resetOverrides() {
+	if estv.overrides != nil {
+		estv.overrides.reset()
+	}
+}
+
+// lookupOverride looks up the title an override pins a lookup to, returning false when no
+// override applies.
+func (estv ElasticTV) lookupOverride(docType Type, params LookupCommonParams, year uint16) (*Title, float64, bool, error) {
+	override, err := estv.findOverride(docType, params, year)
+	if err != nil {
+		log.Printf("Error reading overrides, looking up title without them: %s", err)
+
+		return nil, 0, false, nil
+	}
+
+	if override == nil {
+		return nil, 0, false, nil
+	}
+
+	var title *Title
+
+	var score float64
+
+	switch {
+	case docType == TvShowType:
+		title, score, err = estv.lookupTVShowFromIDs(override.IDs)
+	case override.IDs.IMDb != "":
+		title, score, err = estv.LookupTitleByID(MovieType, IMDbIDSearchAttribute, override.IDs.IMDb)
+	default:
+		title, score, err = estv.LookupTitleByID(MovieType, TMDbIDSearchAttribute, override.IDs.TMDb)
+	}
+
+	return title, score, true, err
+}
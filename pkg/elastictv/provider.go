@@ -1,24 +1,202 @@
 package elastictv
 
 import (
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+var (
+	// ErrNotSupported is returned by providers for searches they cannot serve, as opposed to
+	// searches which found nothing.
+	ErrNotSupported = errors.New("search not supported")
+	// ErrNoProvider is returned when no registered provider can serve a search.
+	ErrNoProvider = errors.New("no registered provider supports search")
 )
 
 type SearchableProvider interface {
 	Name() string
 	Init(estv *ElasticTV) (SearchableProvider, error)
+	Capabilities() Capabilities
 	SearchMovies(SearchItem) error
 	SearchTvShows(SearchItem) error
 	SearchEpisode(SearchItem) error
 }
 
+// AbsoluteNumberingProvider is implemented by providers which can map the absolute episode
+// numbers of a tv show, used by anime releases, to season and episode numbers.
+type AbsoluteNumberingProvider interface {
+	// GetAbsoluteNumbering lists the episodes of a tv show with their season, episode and
+	// absolute numbers.
+	GetAbsoluteNumbering(tvshowIDs IDs) ([]Episode, error)
+}
+
+// SeasonSearchableProvider is implemented by providers which can fetch a season with all its
+// episodes in a single request.
+type SeasonSearchableProvider interface {
+	SearchSeason(SearchItem) error
+	// GetSeasons lists the seasons of a tv show without their episodes.
+	GetSeasons(tvshowIDs IDs) ([]Season, error)
+}
+
+// SyncableProvider is implemented by providers which can list all seasons and episodes of a
+// tv show.
+type SyncableProvider interface {
+	GetTVShowEpisodes(tvshowIDs IDs) ([]Season, []Episode, error)
+}
+
+// Capability lists the search attributes a provider supports for a type.
+type Capability struct {
+	Type       Type
+	Attributes []SearchAttribute
+}
+
+type Capabilities []Capability
+
+func (c Capabilities) Supports(docType Type, attribute SearchAttribute) bool {
+	for _, capability := range c {
+		if capability.Type != docType {
+			continue
+		}
+
+		for _, a := range capability.Attributes {
+			if a == attribute {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// NewNotSupportedError returns the error providers return for a search they cannot serve.
+func NewNotSupportedError(provider string, item SearchItem) error {
+	return fmt.Errorf("%s: %w [ %s ]", provider, ErrNotSupported, item)
+}
+
+type providerOptions struct {
+	// Providers with a lower priority are queried first
+	priority int
+	// Fallback providers are only queried when the providers before them failed or found nothing
+	fallback bool
+}
+
+// AddProvider initialises and registers a provider. Providers are queried in the order
+// they are added unless a priority is set in elastictv.provider.<name>.priority, with
+// providers set as elastictv.provider.<name>.fallback queried last.
 func (estv *ElasticTV) AddProvider(p SearchableProvider) error {
 	provider, err := p.Init(estv)
 	if err != nil {
 		return fmt.Errorf("failed to init provider %s: %w", p.Name(), err)
 	}
 
+	if estv.providerOptions == nil {
+		estv.providerOptions = make(map[string]providerOptions)
+	}
+
+	key := "elastictv.provider." + strings.ToLower(provider.Name())
+	estv.providerOptions[provider.Name()] = providerOptions{
+		priority: viper.GetInt(key + ".priority"),
+		fallback: viper.GetBool(key + ".fallback"),
+	}
+
 	estv.Providers = append(estv.Providers, provider)
 
+	sort.SliceStable(estv.Providers, func(i, j int) bool {
+		a, b := estv.providerOptions[estv.Providers[i].Name()], estv.providerOptions[estv.Providers[j].Name()]
+		if a.fallback != b.fallback {
+			return b.fallback
+		}
+
+		return a.priority < b.priority
+	})
+
 	return nil
 }
+
+func (estv ElasticTV) isFallbackProvider(provider SearchableProvider) bool {
+	return estv.providerOptions[provider.Name()].fallback
+}
+
+// ProvidersFor returns the registered providers supporting searches of the given type and
+// attribute, in order of priority.
+func (estv ElasticTV) ProvidersFor(docType Type, attribute SearchAttribute) []SearchableProvider {
+	providers := make([]SearchableProvider, 0)
+
+	for _, provider := range estv.Providers {
+		if provider.Capabilities().Supports(docType, attribute) {
+			providers = append(providers, provider)
+		}
+	}
+
+	return providers
+}
+
+// searchProviders queries the providers supporting the search items in order of priority.
+// Fallback providers are skipped when isFound reports that the providers before them
+// already found the record, unless any of those providers failed. It returns the search
+// items which any provider served, which excludes items every provider rejected as not
+// supported.
+func (estv ElasticTV) searchProviders(items SearchItems, isFound func() bool,
+	search func(SearchableProvider, SearchItem) error,
+) ([]error, SearchItems) {
+	searchErrors := make([]error, 0)
+	served := make(SearchItems, 0)
+
+	supported := 0
+	for _, item := range items {
+		if len(estv.ProvidersFor(item.Type, item.Attribute)) > 0 {
+			supported++
+		}
+	}
+
+	// Report searches which cannot be served at all, rather than just finding nothing
+	if len(items) > 0 && supported == 0 {
+		return append(searchErrors, fmt.Errorf("%w %s", ErrNoProvider, items)), served
+	}
+
+	failed := false
+
+	for _, provider := range estv.Providers {
+		fallback := estv.isFallbackProvider(provider)
+		if fallback && !failed && isFound() {
+			break
+		}
+
+		queried, providerFailed := false, false
+
+		for _, item := range items {
+			if !provider.Capabilities().Supports(item.Type, item.Attribute) {
+				continue
+			}
+
+			queried = true
+
+			// Providers might not support some combinations (ex an episode without its number)
+			err := search(provider, item)
+			if errors.Is(err, ErrNotSupported) {
+				continue
+			}
+
+			if !served.contains(item) {
+				served = append(served, item)
+			}
+
+			if err != nil {
+				searchErrors = append(searchErrors, err)
+				providerFailed = true
+			}
+		}
+
+		// Any failed primary provider queries the fallback providers, while a fallback provider
+		// which succeeded lets the fallback providers after it be skipped
+		if queried && (fallback || providerFailed) {
+			failed = providerFailed
+		}
+	}
+
+	return searchErrors, served
+}
@@ -0,0 +1,79 @@
+package elastictv
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type testProvider struct {
+	name     string
+	err      error
+	fallback bool
+}
+
+func (p testProvider) Name() string                                { return p.name }
+func (p testProvider) Init(*ElasticTV) (SearchableProvider, error) { return p, nil }
+func (p testProvider) SearchMovies(SearchItem) error               { return p.err }
+func (p testProvider) SearchTvShows(SearchItem) error              { return p.err }
+func (p testProvider) SearchEpisode(SearchItem) error              { return p.err }
+
+func (p testProvider) Capabilities() Capabilities {
+	return Capabilities{{Type: MovieType, Attributes: []SearchAttribute{TitleSearchAttribute}}}
+}
+
+func TestSearchProvidersFallback(t *testing.T) {
+	errFailed := errors.New("failed")
+
+	tests := []struct {
+		name      string
+		providers []testProvider
+		found     bool
+		want      []string
+		served    bool
+	}{
+		{"found by primary", []testProvider{{name: "a"}, {name: "b"}, {name: "c", fallback: true}}, true,
+			[]string{"a", "b"}, true},
+		{"not found", []testProvider{{name: "a"}, {name: "b"}, {name: "c", fallback: true}}, false,
+			[]string{"a", "b", "c"}, true},
+		{"primary failed", []testProvider{{name: "a"}, {name: "b", err: errFailed}, {name: "c", fallback: true}}, true,
+			[]string{"a", "b", "c"}, true},
+		{"not supported is not a failure", []testProvider{{name: "a", err: ErrNotSupported}, {name: "c", fallback: true}}, true,
+			[]string{"a"}, false},
+		{"fallback failed", []testProvider{
+			{name: "a", err: errFailed}, {name: "b", err: errFailed, fallback: true},
+			{name: "c", fallback: true}, {name: "d", fallback: true},
+		}, true, []string{"a", "b", "c"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			estv := ElasticTV{providerOptions: make(map[string]providerOptions)}
+			for _, provider := range tt.providers {
+				estv.Providers = append(estv.Providers, provider)
+				estv.providerOptions[provider.name] = providerOptions{fallback: provider.fallback}
+			}
+
+			queried := make([]string, 0)
+
+			item := NewSearchItem(MovieType, TitleSearchAttribute, "Heat")
+
+			_, served := estv.searchProviders(SearchItems{item},
+				func() bool { return tt.found },
+				func(provider SearchableProvider, item SearchItem) error {
+					queried = append(queried, provider.Name())
+
+					return provider.SearchMovies(item)
+				})
+
+			if !reflect.DeepEqual(queried, tt.want) {
+				t.Errorf("queried providers = %v, want %v", queried, tt.want)
+			}
+
+			// Search items which every provider rejected as not supported are not served
+			if served.contains(item) != tt.served {
+				t.Errorf("served = %v, want served %t", served, tt.served)
+			}
+		})
+	}
+}
@@ -2,9 +2,15 @@ package elastictv
 
 import (
 	"fmt"
+	"math"
+	"slices"
 	"strings"
+	"time"
 )
 
+// exactYearBoost is the boost of titles of the year looked up over titles of nearby years
+const exactYearBoost = 2
+
 type Query struct {
 	Query struct {
 		Bool struct {
@@ -13,47 +19,95 @@ type Query struct {
 			Filter []interface{} `json:"filter,omitempty"`
 		} `json:"bool"`
 	} `json:"query"`
+	Sort []map[string]string `json:"sort,omitempty"`
+	// fuzzy holds the clauses of WithFuzzyTitles, which are only queried by fuzzyQuery
+	fuzzy []interface{}
+	// year is the clause of WithYearProximity, which fuzzyQuery also applies to fuzzy matches
+	year *boolQuery
 }
 
 type queryModels struct {
+	Bool       *boolQuery       `json:"bool,omitempty"`
 	MultiMatch *multiMatchQuery `json:"multi_match,omitempty"`
 	Match      *matchQuery      `json:"match,omitempty"`
 	Term       *termQuery       `json:"term,omitempty"`
+	Terms      *termsQuery      `json:"terms,omitempty"`
 	Range      *rangeQuery      `json:"range,omitempty"`
 }
 
+type boolQuery struct {
+	Should             []interface{} `json:"should,omitempty"`
+	Filter             []interface{} `json:"filter,omitempty"`
+	MinimumShouldMatch int           `json:"minimum_should_match,omitempty"`
+}
+
 type multiMatchQuery struct {
-	Query  string   `json:"query,omitempty"`
-	Fields []string `json:"fields,omitempty"`
+	Query     string   `json:"query,omitempty"`
+	Fields    []string `json:"fields,omitempty"`
+	Fuzziness string   `json:"fuzziness,omitempty"`
+	Boost     float32  `json:"boost,omitempty"`
 }
 
 type termQuery struct {
-	IMDbID       string          `json:"ids.imdb,omitempty"`
-	Type         Type            `json:"type,omitempty"`
-	Query        string          `json:"query,omitempty"`
-	Attribute    SearchAttribute `json:"attribute,omitempty"`
-	TMDbID       int             `json:"ids.tmdb,omitempty"`
-	TVShowTMDbID int             `json:"tvshow_ids.tmdb,omitempty"`
-	Year         uint16          `json:"year,omitempty"`
-	SeasonNo     uint16          `json:"season,omitempty"`
-	EpisodeNo    uint16          `json:"episode,omitempty"`
+	IMDbID         string          `json:"ids.imdb,omitempty"`
+	Normalized     string          `json:"normalized,omitempty"`
+	Type           Type            `json:"type,omitempty"`
+	Query          string          `json:"query,omitempty"`
+	Attribute      SearchAttribute `json:"attribute,omitempty"`
+	TMDbID         int             `json:"ids.tmdb,omitempty"`
+	TVDbID         int             `json:"ids.tvdb,omitempty"`
+	TVmazeID       int             `json:"ids.tvmaze,omitempty"`
+	TraktID        int             `json:"ids.trakt,omitempty"`
+	WikidataID     string          `json:"ids.wikidata,omitempty"`
+	EIDRID         string          `json:"ids.eidr,omitempty"`
+	TVShowTMDbID   int             `json:"tvshow_ids.tmdb,omitempty"`
+	TVShowIMDbID   string          `json:"tvshow_ids.imdb,omitempty"`
+	TVShowTVDbID   int             `json:"tvshow_ids.tvdb,omitempty"`
+	TVShowTVmazeID int             `json:"tvshow_ids.tvmaze,omitempty"`
+	TVShowTraktID  int             `json:"tvshow_ids.trakt,omitempty"`
+	Year           uint16          `json:"year,omitempty"`
+	SeasonNo       *uint16         `json:"season,omitempty"`
+	AirDate        string          `json:"air_date,omitempty"`
+	EpisodeNo      uint16          `json:"episode,omitempty"`
+	AbsoluteNo     uint16          `json:"absolute_episode,omitempty"`
+}
+
+type termsQuery struct {
+	IMDbIDs       []string `json:"ids.imdb,omitempty"`
+	Normalized    []string `json:"normalized,omitempty"`
+	TVShowTMDbIDs []int    `json:"tvshow_ids.tmdb,omitempty"`
+	TVShowIMDbIDs []string `json:"tvshow_ids.imdb,omitempty"`
 }
 
 type matchQuery struct {
-	Director string `json:"credits.director,omitempty"`
-	Actor    string `json:"credits.actor,omitempty"`
-	Other    string `json:"credits.other,omitempty"`
-	Country  string `json:"country,omitempty"`
-	Genre    string `json:"genre,omitempty"`
+	Director string       `json:"credits.director,omitempty"`
+	Actor    string       `json:"credits.actor,omitempty"`
+	Other    string       `json:"credits.other,omitempty"`
+	Country  string       `json:"country,omitempty"`
+	Genre    string       `json:"genre,omitempty"`
+	Title    *matchParams `json:"title,omitempty"`
+}
+
+type matchParams struct {
+	Query     string `json:"query"`
+	Fuzziness string `json:"fuzziness,omitempty"`
 }
 
 type rangeQueryParams struct {
-	GTE uint16 `json:"gte"`
-	LTE uint16 `json:"lte"`
+	GTE   uint16  `json:"gte"`
+	LTE   uint16  `json:"lte"`
+	Boost float32 `json:"boost,omitempty"`
+}
+
+type dateRangeQueryParams struct {
+	GTE string `json:"gte,omitempty"`
+	LTE string `json:"lte,omitempty"`
 }
 
 type rangeQuery struct {
-	Year rangeQueryParams `json:"year"`
+	Year      *rangeQueryParams     `json:"year,omitempty"`
+	AirDate   *dateRangeQueryParams `json:"air_date,omitempty"`
+	Timestamp *dateRangeQueryParams `json:"@timestamp,omitempty"`
 }
 
 func NewQuery() *Query {
@@ -77,11 +131,74 @@ func (q *Query) WithTitles(titles ...string) *Query {
 				Fields: []string{"title", "alias"},
 			},
 		})
+
+		if normalized := NormalizeTitle(title); normalized != "" {
+			q.Query.Bool.Should = append(q.Query.Bool.Should, queryModels{
+				Term: &termQuery{
+					Normalized: normalized,
+				},
+			})
+		}
 	}
 
 	return q
 }
 
+// WithFuzzyTitles matches titles with typos, by edit distance and by shared trigrams, with a
+// lower boost than the matches of WithTitles. The fuzzy matches are not part of the query, so
+// they do not add to the score of exact matches, and are only queried by fuzzyQuery.
+func (q *Query) WithFuzzyTitles(params FuzzyMatchParams, titles ...string) *Query {
+	for _, title := range titles {
+		if title == "" {
+			continue
+		}
+
+		if params.Fuzziness != "" {
+			q.fuzzy = append(q.fuzzy, queryModels{
+				MultiMatch: &multiMatchQuery{
+					Query:     title,
+					Fields:    []string{"title", "alias"},
+					Fuzziness: params.Fuzziness,
+					Boost:     params.Boost,
+				},
+			})
+		}
+
+		if params.NGram != nil && *params.NGram {
+			q.fuzzy = append(q.fuzzy, queryModels{
+				MultiMatch: &multiMatchQuery{
+					Query:  title,
+					Fields: []string{"title.ngram", "alias.ngram"},
+					Boost:  params.Boost,
+				},
+			})
+		}
+	}
+
+	return q
+}
+
+// fuzzyQuery returns the query with the matches of WithFuzzyTitles, or nil if it has none.
+func (q *Query) fuzzyQuery() *Query {
+	if len(q.fuzzy) == 0 {
+		return nil
+	}
+
+	fuzzy := *q
+	fuzzy.Query.Bool.Should = append(slices.Clone(q.Query.Bool.Should), q.fuzzy...)
+	fuzzy.fuzzy = nil
+	fuzzy.year = nil
+
+	// Titles which only match with typos are also boosted by their year
+	for i, clause := range fuzzy.Query.Bool.Should {
+		if model, ok := clause.(queryModels); ok && q.year != nil && model.Bool == q.year {
+			fuzzy.Query.Bool.Should[i] = queryModels{Bool: q.year.withMatches(q.fuzzy)}
+		}
+	}
+
+	return &fuzzy
+}
+
 func (q *Query) WithIMDbID(imdbID string) *Query {
 	if !strings.HasPrefix(imdbID, "tt") {
 		return q
@@ -96,6 +213,39 @@ func (q *Query) WithIMDbID(imdbID string) *Query {
 	return q
 }
 
+// WithIMDbIDs matches documents having any of the IMDb IDs, or episodes of tv shows having any
+// of the tv show IMDb IDs.
+func (q *Query) WithIMDbIDs(imdbIDs, tvshowIMDbIDs []string) *Query {
+	if len(imdbIDs) > 0 {
+		q.Query.Bool.Should = append(q.Query.Bool.Should, queryModels{
+			Terms: &termsQuery{
+				IMDbIDs: imdbIDs,
+			},
+		})
+	}
+
+	if len(tvshowIMDbIDs) > 0 {
+		q.Query.Bool.Should = append(q.Query.Bool.Should, queryModels{
+			Terms: &termsQuery{
+				TVShowIMDbIDs: tvshowIMDbIDs,
+			},
+		})
+	}
+
+	return q
+}
+
+// WithNormalizedTitles filters by any of the normalized titles, as returned by NormalizeTitle.
+func (q *Query) WithNormalizedTitles(normalized ...string) *Query {
+	q.Query.Bool.Filter = append(q.Query.Bool.Filter, queryModels{
+		Terms: &termsQuery{
+			Normalized: normalized,
+		},
+	})
+
+	return q
+}
+
 func (q *Query) WithTMDbID(tmdbID int) *Query {
 	q.Query.Bool.Must = append(q.Query.Bool.Must, queryModels{
 		Term: &termQuery{
@@ -106,6 +256,111 @@ func (q *Query) WithTMDbID(tmdbID int) *Query {
 	return q
 }
 
+// WithExternalID filters by the ID of the given search attribute. IDs read back from search
+// items are decoded from JSON, so whole float64 numbers are accepted as numeric IDs.
+func (q *Query) WithExternalID(attribute SearchAttribute, id any) (*Query, error) {
+	if !attribute.IsID() {
+		return nil, fmt.Errorf("search attribute [%s] is not an ID", attribute)
+	}
+
+	if attribute.IsNumericID() {
+		number, ok := numericID(id)
+		if !ok {
+			return nil, fmt.Errorf("%s [ %v ] is not a number", attribute, id)
+		}
+
+		switch attribute {
+		case TMDbIDSearchAttribute:
+			return q.WithTMDbID(number), nil
+		case TVDbIDSearchAttribute:
+			return q.WithTVDbID(number), nil
+		case TraktIDSearchAttribute:
+			return q.WithTraktID(number), nil
+		default:
+			return q.WithTVmazeID(number), nil
+		}
+	}
+
+	text, ok := id.(string)
+	if !ok || text == "" {
+		return nil, fmt.Errorf("%s [ %v ] is not a valid ID", attribute, id)
+	}
+
+	switch attribute {
+	case IMDbIDSearchAttribute:
+		if !strings.HasPrefix(text, "tt") {
+			return nil, fmt.Errorf("%s [ %s ] is not a valid IMDb ID", attribute, text)
+		}
+
+		return q.WithIMDbID(text), nil
+	case WikidataIDSearchAttribute:
+		return q.WithWikidataID(text), nil
+	default:
+		return q.WithEIDRID(text), nil
+	}
+}
+
+func numericID(id any) (int, bool) {
+	switch number := id.(type) {
+	case int:
+		return number, number > 0
+	case float64:
+		return int(number), number > 0 && number == float64(int(number))
+	default:
+		return 0, false
+	}
+}
+
+func (q *Query) WithWikidataID(wikidataID string) *Query {
+	q.Query.Bool.Must = append(q.Query.Bool.Must, queryModels{
+		Term: &termQuery{
+			WikidataID: wikidataID,
+		},
+	})
+
+	return q
+}
+
+func (q *Query) WithEIDRID(eidrID string) *Query {
+	q.Query.Bool.Must = append(q.Query.Bool.Must, queryModels{
+		Term: &termQuery{
+			EIDRID: eidrID,
+		},
+	})
+
+	return q
+}
+
+func (q *Query) WithTVDbID(tvdbID int) *Query {
+	q.Query.Bool.Must = append(q.Query.Bool.Must, queryModels{
+		Term: &termQuery{
+			TVDbID: tvdbID,
+		},
+	})
+
+	return q
+}
+
+func (q *Query) WithTVmazeID(tvmazeID int) *Query {
+	q.Query.Bool.Must = append(q.Query.Bool.Must, queryModels{
+		Term: &termQuery{
+			TVmazeID: tvmazeID,
+		},
+	})
+
+	return q
+}
+
+func (q *Query) WithTraktID(traktID int) *Query {
+	q.Query.Bool.Must = append(q.Query.Bool.Must, queryModels{
+		Term: &termQuery{
+			TraktID: traktID,
+		},
+	})
+
+	return q
+}
+
 func (q *Query) WithActors(names ...string) *Query {
 	for _, name := range names {
 		q.Query.Bool.Should = append(q.Query.Bool.Should, queryModels{
@@ -155,11 +410,106 @@ func (q *Query) WithCountries(countries ...string) *Query {
 }
 
 func (q *Query) WithYearRange(year, diff uint16) *Query {
+	if year == 0 {
+		return q
+	}
+
+	from, to := yearRange(year, diff)
+
+	q.Query.Bool.Must = append(q.Query.Bool.Must, queryModels{
+		Range: &rangeQuery{
+			Year: &rangeQueryParams{
+				GTE: from,
+				LTE: to,
+			},
+		},
+	})
+
+	return q
+}
+
+// WithYearProximity boosts titles of the given year, and less so titles within diff years of it,
+// without excluding titles of other years. The year only boosts titles which match any of the
+// clauses added before it, such as their title or credits, so the year alone cannot make a title
+// a candidate.
+func (q *Query) WithYearProximity(year, diff uint16) *Query {
+	if year == 0 || len(q.Query.Bool.Should) == 0 {
+		return q
+	}
+
+	from, to := yearRange(year, diff)
+
+	q.year = &boolQuery{
+		Filter: []interface{}{
+			queryModels{
+				Bool: &boolQuery{
+					Should:             slices.Clone(q.Query.Bool.Should),
+					MinimumShouldMatch: 1,
+				},
+			},
+		},
+		Should: []interface{}{
+			queryModels{
+				Range: &rangeQuery{
+					Year: &rangeQueryParams{
+						GTE:   year,
+						LTE:   year,
+						Boost: exactYearBoost,
+					},
+				},
+			},
+			queryModels{
+				Range: &rangeQuery{
+					Year: &rangeQueryParams{
+						GTE: from,
+						LTE: to,
+					},
+				},
+			},
+		},
+		MinimumShouldMatch: 1,
+	}
+
+	q.Query.Bool.Should = append(q.Query.Bool.Should, queryModels{Bool: q.year})
+
+	return q
+}
+
+// withMatches returns a copy of the year clause of WithYearProximity which also boosts titles
+// matching any of the given clauses.
+func (b *boolQuery) withMatches(clauses []interface{}) *boolQuery {
+	matches := *b.Filter[0].(queryModels).Bool
+	matches.Should = append(slices.Clip(matches.Should), clauses...)
+
+	year := *b
+	year.Filter = []interface{}{queryModels{Bool: &matches}}
+
+	return &year
+}
+
+// yearRange returns the years within diff years of year, without overflowing uint16.
+func yearRange(year, diff uint16) (uint16, uint16) {
+	from := uint16(0)
+	if year > diff {
+		from = year - diff
+	}
+
+	to := uint16(math.MaxUint16)
+	if math.MaxUint16-year > diff {
+		to = year + diff
+	}
+
+	return from, to
+}
+
+// WithAirDateRange filters by the air date from and to the given dates (ex 2024-03-15), where
+// an empty date leaves the range open.
+func (q *Query) WithAirDateRange(from, to string) *Query {
 	q.Query.Bool.Must = append(q.Query.Bool.Must, queryModels{
 		Range: &rangeQuery{
-			Year: rangeQueryParams{
-				GTE: year - diff,
-				LTE: year + diff,
+			AirDate: &dateRangeQueryParams{
+				GTE: from,
+				LTE: to,
 			},
 		},
 	})
@@ -167,6 +517,71 @@ func (q *Query) WithYearRange(year, diff uint16) *Query {
 	return q
 }
 
+// WithTVShowTMDbIDs filters by any of the TMDb IDs of tv shows.
+func (q *Query) WithTVShowTMDbIDs(tmdbIDs ...int) *Query {
+	q.Query.Bool.Must = append(q.Query.Bool.Must, queryModels{
+		Terms: &termsQuery{
+			TVShowTMDbIDs: tmdbIDs,
+		},
+	})
+
+	return q
+}
+
+// SortByAirDate sorts episodes in the order they aired, or the reverse order when not ascending.
+func (q *Query) SortByAirDate(ascending bool) *Query {
+	return q.sortBy(ascending, "air_date", "season", "episode")
+}
+
+// WithTimestampRange filters by when documents were written, where a zero time leaves the range open.
+func (q *Query) WithTimestampRange(from, to time.Time) *Query {
+	params := &dateRangeQueryParams{}
+
+	if !from.IsZero() {
+		params.GTE = from.UTC().Format(timeFormat)
+	}
+
+	if !to.IsZero() {
+		params.LTE = to.UTC().Format(timeFormat)
+	}
+
+	q.Query.Bool.Must = append(q.Query.Bool.Must, queryModels{
+		Range: &rangeQuery{
+			Timestamp: params,
+		},
+	})
+
+	return q
+}
+
+func (q *Query) WithAttribute(attribute SearchAttribute) *Query {
+	q.Query.Bool.Must = append(q.Query.Bool.Must, queryModels{
+		Term: &termQuery{
+			Attribute: attribute,
+		},
+	})
+
+	return q
+}
+
+// SortByTimestamp sorts documents by when they were written, or the reverse order when not ascending.
+func (q *Query) SortByTimestamp(ascending bool) *Query {
+	return q.sortBy(ascending, "@timestamp")
+}
+
+func (q *Query) sortBy(ascending bool, fields ...string) *Query {
+	order := "asc"
+	if !ascending {
+		order = "desc"
+	}
+
+	for _, field := range fields {
+		q.Sort = append(q.Sort, map[string]string{field: order})
+	}
+
+	return q
+}
+
 func (q *Query) WithType(docType Type) *Query {
 	q.Query.Bool.Must = append(q.Query.Bool.Must, queryModels{
 		Term: &termQuery{
@@ -187,10 +602,80 @@ func (q *Query) WithTVShowTMDbID(tmdbTvShowID int) *Query {
 	return q
 }
 
+// WithTVShowIDs filters episodes of a tv show matching any of the given IDs, since
+// episodes indexed by different providers might only have the IDs known to that provider.
+func (q *Query) WithTVShowIDs(ids IDs) *Query {
+	terms := make([]interface{}, 0)
+
+	if ids.TMDb > 0 {
+		terms = append(terms, queryModels{Term: &termQuery{TVShowTMDbID: ids.TMDb}})
+	}
+
+	if strings.HasPrefix(ids.IMDb, "tt") {
+		terms = append(terms, queryModels{Term: &termQuery{TVShowIMDbID: ids.IMDb}})
+	}
+
+	if ids.TVDb > 0 {
+		terms = append(terms, queryModels{Term: &termQuery{TVShowTVDbID: ids.TVDb}})
+	}
+
+	if ids.TVmaze > 0 {
+		terms = append(terms, queryModels{Term: &termQuery{TVShowTVmazeID: ids.TVmaze}})
+	}
+
+	if ids.Trakt > 0 {
+		terms = append(terms, queryModels{Term: &termQuery{TVShowTraktID: ids.Trakt}})
+	}
+
+	q.Query.Bool.Filter = append(q.Query.Bool.Filter, queryModels{
+		Bool: &boolQuery{
+			Should:             terms,
+			MinimumShouldMatch: 1,
+		},
+	})
+
+	return q
+}
+
+func (q *Query) WithAirDate(airDate string) *Query {
+	q.Query.Bool.Must = append(q.Query.Bool.Must, queryModels{
+		Term: &termQuery{
+			AirDate: airDate,
+		},
+	})
+
+	return q
+}
+
+// WithSeasonNumber filters by season, including season 0 which holds the specials of a tv show.
 func (q *Query) WithSeasonNumber(season uint16) *Query {
 	q.Query.Bool.Must = append(q.Query.Bool.Must, queryModels{
 		Term: &termQuery{
-			SeasonNo: season,
+			SeasonNo: &season,
+		},
+	})
+
+	return q
+}
+
+// WithEpisodeTitle matches the title of episodes, which are scored by how well they match. Titles
+// with typos are matched with a lower score than titles which match exactly.
+func (q *Query) WithEpisodeTitle(title string) *Query {
+	q.Query.Bool.Must = append(q.Query.Bool.Must, queryModels{
+		Bool: &boolQuery{
+			Should: []interface{}{
+				queryModels{
+					Match: &matchQuery{
+						Title: &matchParams{Query: title},
+					},
+				},
+				queryModels{
+					Match: &matchQuery{
+						Title: &matchParams{Query: title, Fuzziness: "AUTO"},
+					},
+				},
+			},
+			MinimumShouldMatch: 1,
 		},
 	})
 
@@ -211,6 +696,20 @@ func (q *Query) WithEpisodeNumber(episode uint16) *Query {
 	return q
 }
 
+func (q *Query) WithAbsoluteEpisodeNumber(episode uint16) *Query {
+	if episode == 0 {
+		return q
+	}
+
+	q.Query.Bool.Must = append(q.Query.Bool.Must, queryModels{
+		Term: &termQuery{
+			AbsoluteNo: episode,
+		},
+	})
+
+	return q
+}
+
 func (q *Query) WithGenres(genres ...string) *Query {
 	for _, genre := range genres {
 		q.Query.Bool.Should = append(q.Query.Bool.Should, queryModels{
@@ -250,19 +749,21 @@ func (q *Query) WithSearchItem(searchItem SearchItem) *Query {
 		})
 	}
 
-	if searchItem.SeasonNo > 0 && searchItem.EpisodeNo > 0 {
-		q.Query.Bool.Must = append(q.Query.Bool.Must,
-			queryModels{
-				Term: &termQuery{
-					SeasonNo: searchItem.SeasonNo,
-				},
+	// Season 0 is kept in search items of episodes and seasons since it is the season of specials
+	if searchItem.Type == EpisodeType || searchItem.Type == SeasonType {
+		q.Query.Bool.Must = append(q.Query.Bool.Must, queryModels{
+			Term: &termQuery{
+				SeasonNo: &searchItem.SeasonNo,
 			},
-			queryModels{
-				Term: &termQuery{
-					EpisodeNo: searchItem.EpisodeNo,
-				},
+		})
+	}
+
+	if searchItem.EpisodeNo > 0 {
+		q.Query.Bool.Must = append(q.Query.Bool.Must, queryModels{
+			Term: &termQuery{
+				EpisodeNo: searchItem.EpisodeNo,
 			},
-		)
+		})
 	}
 
 	return q
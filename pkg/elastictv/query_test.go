@@ -0,0 +1,179 @@
+package elastictv
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestWithExternalID(t *testing.T) {
+	tests := []struct {
+		name      string
+		attribute SearchAttribute
+		id        any
+		want      string
+	}{
+		{"imdb", IMDbIDSearchAttribute, "tt0133093", `"ids.imdb":"tt0133093"`},
+		{"tvdb", TVDbIDSearchAttribute, 81189, `"ids.tvdb":81189`},
+		{"decoded number", TraktIDSearchAttribute, float64(1388), `"ids.trakt":1388`},
+		{"eidr", EIDRIDSearchAttribute, "10.5240/1489-49A2-3956-4B2D-FE16-5", `"ids.eidr":"10.5240/1489-49A2-3956-4B2D-FE16-5"`},
+		{"text tmdb", TMDbIDSearchAttribute, "603", ""},
+		{"fractional tvmaze", TVmazeIDSearchAttribute, 1.5, ""},
+		{"number imdb", IMDbIDSearchAttribute, 133093, ""},
+		{"imdb without prefix", IMDbIDSearchAttribute, "12345", ""},
+		{"not an id", TitleSearchAttribute, "The Matrix", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := NewQuery().WithExternalID(tt.attribute, tt.id)
+			if tt.want == "" {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			body, err := json.Marshal(query)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !strings.Contains(string(body), tt.want) {
+				t.Errorf("query %s does not contain %s", body, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchAttributeIsResolvableID(t *testing.T) {
+	for _, attribute := range []SearchAttribute{IMDbIDSearchAttribute, TMDbIDSearchAttribute, WikidataIDSearchAttribute} {
+		if !attribute.IsResolvableID() {
+			t.Errorf("%s is not resolvable", attribute)
+		}
+	}
+
+	for _, attribute := range []SearchAttribute{TitleSearchAttribute, ActorSearchAttribute, EIDRIDSearchAttribute} {
+		if attribute.IsResolvableID() {
+			t.Errorf("%s is resolvable", attribute)
+		}
+	}
+}
+
+func TestWithSearchItemSeason(t *testing.T) {
+	tests := []struct {
+		name string
+		item SearchItem
+		want bool
+	}{
+		{"season", NewSearchItem(SeasonType, TMDbIDSearchAttribute, 1396).WithSeasonNo(2), true},
+		{"specials", NewSearchItem(SeasonType, TMDbIDSearchAttribute, 1396).WithSeasonNo(0), true},
+		{"episode", NewSearchItem(EpisodeType, TMDbIDSearchAttribute, 1396).WithSeasonNo(0).WithEpisodeNo(1), true},
+		{"tv show", NewSearchItem(TvShowType, TitleSearchAttribute, "Breaking Bad"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := json.Marshal(NewQuery().WithSearchItem(tt.item))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			term := fmt.Sprintf(`"season":%d`, tt.item.SeasonNo)
+			if strings.Contains(string(body), term) != tt.want {
+				t.Errorf("query %s contains %s = %t, want %t", body, term, !tt.want, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithFuzzyTitles(t *testing.T) {
+	ngram := true
+	query := NewQuery().
+		WithTitles("Interstelar").
+		WithFuzzyTitles(FuzzyMatchParams{Fuzziness: "AUTO", NGram: &ngram, Boost: 0.5}, "Interstelar")
+
+	body, err := json.Marshal(query)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(string(body), "fuzziness") || strings.Contains(string(body), "title.ngram") {
+		t.Errorf("exact query %s contains fuzzy matches", body)
+	}
+
+	fuzzy, err := json.Marshal(query.fuzzyQuery())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(string(fuzzy), `"fuzziness":"AUTO"`) || !strings.Contains(string(fuzzy), "title.ngram") {
+		t.Errorf("fuzzy query %s does not contain fuzzy matches", fuzzy)
+	}
+
+	if again, _ := json.Marshal(query); string(again) != string(body) {
+		t.Errorf("fuzzyQuery changed the exact query to %s", again)
+	}
+
+	if NewQuery().WithTitles("Interstellar").WithFuzzyTitles(FuzzyMatchParams{}, "Interstellar").fuzzyQuery() != nil {
+		t.Error("expected no fuzzy query without fuzziness or ngram")
+	}
+}
+
+func TestGetFuzzyMatchParamsNGram(t *testing.T) {
+	viper.Set("elastictv.title.fuzzy.ngram", true)
+	t.Cleanup(viper.Reset)
+
+	if params := (LookupCommonParams{}).getFuzzyMatchParams(); params.NGram == nil || !*params.NGram {
+		t.Error("expected the configured ngram matching")
+	}
+
+	disabled := false
+	if params := (LookupCommonParams{Fuzzy: FuzzyMatchParams{NGram: &disabled}}).getFuzzyMatchParams(); *params.NGram {
+		t.Error("expected ngram matching to be turned off by the lookup")
+	}
+}
+
+func TestWithYearProximity(t *testing.T) {
+	if query := NewQuery().WithType(MovieType).WithYearProximity(1999, 1); len(query.Query.Bool.Should) != 0 {
+		t.Errorf("expected no year clause without clauses to match, got %+v", query.Query.Bool.Should)
+	}
+
+	query := NewQuery().
+		WithTitles("The Matrix").
+		WithFuzzyTitles(FuzzyMatchParams{Fuzziness: "AUTO", Boost: 0.5}, "The Matrix").
+		WithYearProximity(1999, 1)
+
+	if len(query.Query.Bool.Should) != 4 {
+		t.Fatalf("expected 3 title clauses and a year clause, got %d clauses", len(query.Query.Bool.Should))
+	}
+
+	year, ok := query.Query.Bool.Should[3].(queryModels)
+	if !ok || year.Bool == nil || year.Bool.MinimumShouldMatch != 1 || len(year.Bool.Filter) != 1 {
+		t.Fatalf("year clause %+v does not require a title match", query.Query.Bool.Should[3])
+	}
+
+	matches := year.Bool.Filter[0].(queryModels).Bool
+	if len(matches.Should) != 3 || matches.MinimumShouldMatch != 1 {
+		t.Errorf("year clause requires %d of %d clauses, want 1 of 3", matches.MinimumShouldMatch, len(matches.Should))
+	}
+
+	fuzzy := query.fuzzyQuery()
+	fuzzyYear := fuzzy.Query.Bool.Should[3].(queryModels).Bool
+
+	if got := len(fuzzyYear.Filter[0].(queryModels).Bool.Should); got != 4 {
+		t.Errorf("fuzzy year clause requires any of %d clauses, want 4", got)
+	}
+
+	if len(matches.Should) != 3 {
+		t.Error("fuzzyQuery changed the year clause of the exact query")
+	}
+}
@@ -3,12 +3,13 @@ package elastictv
 import (
 	"fmt"
 	"strings"
-	"time"
 )
 
 type SearchAttribute int
 
-var searchAttributesList = [...]string{"title", "director", "actor", "imdb_id", "tmdb_id"}
+var searchAttributesList = [...]string{
+	"title", "director", "actor", "imdb_id", "tmdb_id", "tvdb_id", "trakt_id", "tvmaze_id", "wikidata_id", "eidr_id",
+}
 
 const (
 	TitleSearchAttribute SearchAttribute = iota + 1
@@ -16,21 +17,75 @@ const (
 	ActorSearchAttribute
 	IMDbIDSearchAttribute
 	TMDbIDSearchAttribute
+	TVDbIDSearchAttribute
+	TraktIDSearchAttribute
+	TVmazeIDSearchAttribute
+	WikidataIDSearchAttribute
+	EIDRIDSearchAttribute
 )
 
 func (id SearchAttribute) MarshalText() ([]byte, error) {
 	return []byte(searchAttributesList[id-1]), nil
 }
 
+func (id *SearchAttribute) UnmarshalText(text []byte) error {
+	attribute, err := ParseSearchAttribute(string(text))
+	if err != nil {
+		return err
+	}
+
+	*id = attribute
+
+	return nil
+}
+
+// ParseSearchAttribute returns the search attribute with the given name (ex imdb_id).
+func ParseSearchAttribute(name string) (SearchAttribute, error) {
+	for i, attribute := range searchAttributesList {
+		if attribute == name {
+			return SearchAttribute(i + 1), nil
+		}
+	}
+
+	return 0, fmt.Errorf("search attribute [%s] is not valid", name)
+}
+
 func (id SearchAttribute) String() string {
 	return searchAttributesList[id-1]
 }
 
+// IsID returns true if the search attribute is the ID of a title in some database.
+func (id SearchAttribute) IsID() bool {
+	switch id {
+	case IMDbIDSearchAttribute, TMDbIDSearchAttribute, TVDbIDSearchAttribute, TraktIDSearchAttribute,
+		TVmazeIDSearchAttribute, WikidataIDSearchAttribute, EIDRIDSearchAttribute:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsResolvableID returns true if providers can be searched by the ID of the search attribute.
+// EIDR IDs are only matched against cached titles since no provider resolves them.
+func (id SearchAttribute) IsResolvableID() bool {
+	return id.IsID() && id != EIDRIDSearchAttribute
+}
+
+// IsNumericID returns true if the search attribute is an ID which is a number rather than text.
+func (id SearchAttribute) IsNumericID() bool {
+	switch id {
+	case TMDbIDSearchAttribute, TVDbIDSearchAttribute, TraktIDSearchAttribute, TVmazeIDSearchAttribute:
+		return true
+	default:
+		return false
+	}
+}
+
 type SearchItem struct {
 	Query     any             `json:"query,omitempty"`
 	Attribute SearchAttribute `json:"attribute,omitempty"`
 	Year      uint16          `json:"year,omitempty"`
-	SeasonNo  uint16          `json:"season,omitempty"`
+	SeasonNo  uint16          `json:"season"`
 	EpisodeNo uint16          `json:"episode,omitempty"`
 	Type      Type            `json:"type,omitempty"`
 	Timestamp string          `json:"@timestamp,omitempty"`
@@ -38,6 +93,16 @@ type SearchItem struct {
 
 type SearchItems []SearchItem
 
+func (s SearchItems) contains(item SearchItem) bool {
+	for _, i := range s {
+		if i == item {
+			return true
+		}
+	}
+
+	return false
+}
+
 func NewSearchItem(docType Type, attribute SearchAttribute, query any) SearchItem {
 	params := SearchItem{
 		Type:      docType,
@@ -56,10 +121,10 @@ func (s SearchItem) WithYear(year uint16) SearchItem {
 	return s
 }
 
+// WithSeasonNo sets the season of episode and season search items, where season 0 holds the
+// specials of a tv show.
 func (s SearchItem) WithSeasonNo(seasonNo uint16) SearchItem {
-	if seasonNo > 0 {
-		s.SeasonNo = seasonNo
-	}
+	s.SeasonNo = seasonNo
 
 	return s
 }
@@ -75,15 +140,18 @@ func (s SearchItem) WithEpisodeNo(episodeNo uint16) SearchItem {
 func (s SearchItem) String() string {
 	text := fmt.Sprintf("%v (%s %s)", s.Query, s.Attribute, s.Type)
 
-	if s.SeasonNo > 0 && s.EpisodeNo > 0 {
+	switch {
+	case s.EpisodeNo > 0:
 		text += fmt.Sprintf(" S%02dE%02d", s.SeasonNo, s.EpisodeNo)
+	case s.Type == SeasonType:
+		text += fmt.Sprintf(" S%02d", s.SeasonNo)
 	}
 
 	return strings.TrimSpace(text)
 }
 
 func (estv ElasticTV) indexSearchItem(item SearchItem) error {
-	item.Timestamp = time.Now().UTC().Format("2006-01-02T15:04:05.0000000")
+	item.Timestamp = CurrentTimestamp()
 	if err := estv.index(estv.Index.Search, "", item); err != nil {
 		return fmt.Errorf("failed to index search item : %w", err)
 	}
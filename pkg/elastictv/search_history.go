@@ -0,0 +1,167 @@
+package elastictv
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const defaultSearchHistorySize = 100
+
+// SearchHistoryFilter selects search items by their type, attribute and when they ran. Zero
+// values match all search items.
+type SearchHistoryFilter struct {
+	Type      Type
+	Attribute SearchAttribute
+	From      time.Time
+	To        time.Time
+	// Size is the maximum number of search items listed, which defaults to 100
+	Size int
+	// All has to be set to purge or expire the whole history with a filter which matches every
+	// search item
+	All bool
+}
+
+// ErrUnfilteredHistory is returned when purging or expiring the search history with an empty
+// filter which does not set All.
+var ErrUnfilteredHistory = errors.New("search history filter is empty")
+
+func (f SearchHistoryFilter) isEmpty() bool {
+	return f.Type == 0 && f.Attribute == 0 && f.From.IsZero() && f.To.IsZero()
+}
+
+// SearchHistoryEntry is a search item which ran, with when it expires and is run again.
+type SearchHistoryEntry struct {
+	ID string
+	SearchItem
+	ExpiresAt time.Time
+	Expired   bool
+}
+
+func (f SearchHistoryFilter) query() *Query {
+	query := NewQuery()
+
+	if f.Type > 0 {
+		query = query.WithType(f.Type)
+	}
+
+	if f.Attribute > 0 {
+		query = query.WithAttribute(f.Attribute)
+	}
+
+	if !f.From.IsZero() || !f.To.IsZero() {
+		query = query.WithTimestampRange(f.From, f.To)
+	}
+
+	return query
+}
+
+// SearchHistory lists the search items which ran, the most recent first.
+func (estv ElasticTV) SearchHistory(filter SearchHistoryFilter) ([]SearchHistoryEntry, error) {
+	size := filter.Size
+	if size <= 0 {
+		size = defaultSearchHistorySize
+	}
+
+	result, err := estv.search(filter.query().SortByTimestamp(false), estv.Index.Search, size)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]SearchHistoryEntry, 0, len(result.Hits.Hits))
+
+	for _, hit := range result.Hits.Hits {
+		item := SearchItem{}
+		if err := json.Unmarshal(hit.Source, &item); err != nil {
+			return nil, fmt.Errorf("error parsing source: %w", err)
+		}
+
+		// IDs are decoded as float64 and need to be integers to query them again
+		if number, ok := item.Query.(float64); ok {
+			item.Query = int(number)
+		}
+
+		entry := SearchHistoryEntry{ID: hit.ID, SearchItem: item, Expired: estv.RequiresUpdate(item.Timestamp)}
+
+		if timestamp, err := time.Parse(timeFormat, item.Timestamp); err == nil {
+			entry.ExpiresAt = timestamp.AddDate(0, 0, estv.updateAfterDays)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// SearchItemTitles returns the cached titles a search item produced, which are the titles with
+// the ID of ID searches and the best matches of other searches. Episode and season searches
+// return their tv show.
+func (estv ElasticTV) SearchItemTitles(item SearchItem, size int) ([]Title, error) {
+	query := NewQuery()
+
+	docType := item.Type
+	if docType == EpisodeType || docType == SeasonType {
+		docType = TvShowType
+	}
+
+	switch {
+	case item.Attribute.IsID():
+		idQuery, err := query.WithExternalID(item.Attribute, item.Query)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get titles of search item [ %s ]: %w", item, err)
+		}
+
+		query = idQuery.WithType(docType)
+	case item.Attribute == TitleSearchAttribute:
+		query = query.WithTitles(fmt.Sprintf("%v", item.Query)).WithType(docType)
+	case item.Attribute == DirectorSearchAttribute:
+		query = query.WithDirectors(fmt.Sprintf("%v", item.Query)).WithType(docType)
+	case item.Attribute == ActorSearchAttribute:
+		query = query.WithActors(fmt.Sprintf("%v", item.Query)).WithType(docType)
+	default:
+		return nil, fmt.Errorf("cannot get titles of search item [ %s ]", item)
+	}
+
+	if item.Type == MovieType {
+		query = query.WithYearRange(item.Year, MovieYearTolerance())
+	}
+
+	result, err := estv.search(query, estv.Index.Title, size)
+	if err != nil {
+		return nil, err
+	}
+
+	titles := make([]Title, 0, len(result.Hits.Hits))
+
+	for _, hit := range result.Hits.Hits {
+		title := Title{}
+		if err := json.Unmarshal(hit.Source, &title); err != nil {
+			return nil, fmt.Errorf("error parsing source: %w", err)
+		}
+
+		titles = append(titles, title)
+	}
+
+	return titles, nil
+}
+
+// PurgeSearchHistory deletes the search items matching the filter, returning how many were
+// deleted. Deleted searches run again the next time they are looked up.
+func (estv ElasticTV) PurgeSearchHistory(filter SearchHistoryFilter) (int, error) {
+	if filter.isEmpty() && !filter.All {
+		return 0, ErrUnfilteredHistory
+	}
+
+	return estv.deleteByQuery(filter.query(), estv.Index.Search)
+}
+
+// ExpireSearchHistory expires the search items matching the filter, returning how many were
+// expired. Unlike purged searches, expired searches are kept in the history.
+func (estv ElasticTV) ExpireSearchHistory(filter SearchHistoryFilter) (int, error) {
+	if filter.isEmpty() && !filter.All {
+		return 0, ErrUnfilteredHistory
+	}
+
+	return estv.updateByQuery(filter.query(), estv.Index.Search, "@timestamp", ExpiredTimestamp)
+}
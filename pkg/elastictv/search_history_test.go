@@ -0,0 +1,92 @@
+package elastictv
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPurgeAndExpireSearchHistory(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  SearchHistoryFilter
+		want    []string
+		wantErr error
+	}{
+		{"no filter", SearchHistoryFilter{Size: 10}, nil, ErrUnfilteredHistory},
+		{"type", SearchHistoryFilter{Type: MovieType}, []string{`"type":"movie"`}, nil},
+		{"attribute", SearchHistoryFilter{Attribute: TitleSearchAttribute}, []string{`"attribute":"title"`}, nil},
+		{"since", SearchHistoryFilter{From: time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)},
+			[]string{`"@timestamp":{"gte":"2025-01-02T00:00:00.0000000"}`}, nil},
+		{"all", SearchHistoryFilter{All: true}, []string{`"query":{"bool":{}}`}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			estv, requests := newTestElasticTV(t, func(request esRequest) string {
+				return `{"deleted":3,"updated":3}`
+			})
+
+			purged, err := estv.PurgeSearchHistory(tt.filter)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("purge error = %v, want %v", err, tt.wantErr)
+			}
+
+			expired, err := estv.ExpireSearchHistory(tt.filter)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expire error = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr != nil {
+				if len(*requests) > 0 {
+					t.Errorf("unfiltered history was changed by %+v", *requests)
+				}
+
+				return
+			}
+
+			if purged != 3 || expired != 3 {
+				t.Errorf("purged %d and expired %d search items, want 3", purged, expired)
+			}
+
+			if len(*requests) != 2 || (*requests)[0].Path != "/search/_delete_by_query" ||
+				(*requests)[1].Path != "/search/_update_by_query" {
+				t.Fatalf("requests = %+v, want a delete and an update by query", *requests)
+			}
+
+			for _, request := range *requests {
+				for _, want := range tt.want {
+					if !strings.Contains(request.Body, want) {
+						t.Errorf("%s query %s does not contain %s", request.Path, request.Body, want)
+					}
+				}
+			}
+
+			if body := (*requests)[1].Body; !strings.Contains(body, `"value":"`+ExpiredTimestamp+`"`) {
+				t.Errorf("expire script %s does not set the expired timestamp", body)
+			}
+		})
+	}
+}
+
+func TestSearchHistoryExpiresAt(t *testing.T) {
+	estv, _ := newTestElasticTV(t, func(request esRequest) string {
+		return `{"hits":{"total":{"value":1},"hits":[{"_id":"1","_source":` +
+			`{"query":"Heat","attribute":"title","type":"movie","@timestamp":"2025-01-02T03:04:05.0000000"}}]}}`
+	})
+	estv.updateAfterDays = 7
+
+	entries, err := estv.SearchHistory(SearchHistoryFilter{})
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("SearchHistory() = %+v, %v", entries, err)
+	}
+
+	if want := time.Date(2025, 1, 9, 3, 4, 5, 0, time.UTC); !entries[0].ExpiresAt.Equal(want) {
+		t.Errorf("expires at %s, want %s", entries[0].ExpiresAt, want)
+	}
+
+	if !entries[0].Expired {
+		t.Error("search item is not expired")
+	}
+}
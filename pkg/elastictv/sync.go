@@ -0,0 +1,208 @@
+package elastictv
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+// maxSyncEpisodes is the maximum number of indexed episodes of a tv show which are compared
+// when syncing, which is the default maximum result window of Elasticsearch.
+const maxSyncEpisodes = 10000
+
+// SyncReport lists the episodes changed by a sync of a tv show.
+type SyncReport struct {
+	TVShow    *Title
+	Seasons   int
+	Added     []Episode
+	Updated   []Episode
+	Removed   []Episode
+	Unchanged int
+	// Duplicates are episodes indexed more than once, which are left for the user to remove
+	Duplicates []Episode
+}
+
+func (r SyncReport) String() string {
+	return fmt.Sprintf("%s: %d seasons, %d episodes added, %d updated, %d removed, %d unchanged and %d duplicated",
+		r.TVShow.Title, r.Seasons, len(r.Added), len(r.Updated), len(r.Removed), r.Unchanged, len(r.Duplicates))
+}
+
+// SyncTVShow caches all seasons and episodes of a tv show from the first provider able to list
+// them. Episodes of that provider which it no longer lists are removed, while episodes which are
+// indexed more than once are reported as duplicates and left as they are.
+func (estv ElasticTV) SyncTVShow(tmdbID int) (*SyncReport, error) {
+	tvshow, _, err := estv.lookupTVShowFromIDs(IDs{TMDb: tmdbID})
+	if err != nil {
+		return nil, fmt.Errorf("error looking up tv show with TMDb ID %d: %w", tmdbID, err)
+	}
+
+	var provider SearchableProvider
+
+	for _, p := range estv.Providers {
+		if _, ok := p.(SyncableProvider); ok {
+			provider = p
+
+			break
+		}
+	}
+
+	if provider == nil {
+		return nil, fmt.Errorf("%w [ sync %s ]", ErrNoProvider, tvshow.Title)
+	}
+
+	log.Printf("%s: Syncing tvshow [ %s ]", provider.Name(), tvshow.Title)
+
+	seasons, episodes, err := provider.(SyncableProvider).GetTVShowEpisodes(tvshow.IDs)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := estv.getEpisodeRecords(NewQuery().WithTVShowIDs(tvshow.IDs))
+	if err != nil {
+		return nil, err
+	}
+
+	report := &SyncReport{TVShow: tvshow, Seasons: len(seasons)}
+
+	for _, duplicate := range existing.duplicates {
+		report.Duplicates = append(report.Duplicates, duplicate.episode)
+	}
+
+	docs := make([]BulkDocument, 0)
+	listed := make(map[string]bool)
+
+	for _, episode := range episodes {
+		episode.TVShowIDs = episode.TVShowIDs.merge(tvshow.IDs)
+
+		recordID, current := existing.find(episode.SeasonNo, episode.EpisodeNo)
+		if recordID != "" {
+			listed[recordID] = true
+		}
+
+		merged := estv.mergeEpisode(episode, current)
+
+		switch {
+		case recordID == "":
+			report.Added = append(report.Added, merged)
+		case isSameEpisode(merged, current):
+			report.Unchanged++
+
+			continue
+		default:
+			report.Updated = append(report.Updated, merged)
+		}
+
+		merged.Timestamp = CurrentTimestamp()
+		docs = append(docs, BulkDocument{ID: recordID, Document: merged})
+	}
+
+	for _, record := range existing.records {
+		if !listed[record.id] && record.episode.Source == provider.Name() {
+			report.Removed = append(report.Removed, record.episode)
+			docs = append(docs, BulkDocument{ID: record.id, Delete: true})
+		}
+	}
+
+	var errors *multierror.Error
+
+	if err := estv.BulkIndex(estv.Index.Episode, docs); err != nil {
+		errors = multierror.Append(errors, err)
+	}
+
+	for _, season := range seasons {
+		if err := estv.UpsertSeason(season); err != nil {
+			errors = multierror.Append(errors, err)
+		}
+	}
+
+	if err := estv.RefreshIndices(estv.Index.Episode); err != nil {
+		errors = multierror.Append(errors, err)
+	}
+
+	return report, errors.ErrorOrNil()
+}
+
+type episodeNumber struct {
+	seasonNo  uint16
+	episodeNo uint16
+}
+
+type episodeRecord struct {
+	id      string
+	episode Episode
+}
+
+// episodeRecords holds the indexed episodes of a tv show by season and episode number. When an
+// episode is indexed more than once the most recently written record is used and the others
+// are kept as duplicates, which are neither updated nor removed.
+type episodeRecords struct {
+	records    map[episodeNumber]episodeRecord
+	duplicates []episodeRecord
+}
+
+func (e episodeRecords) find(seasonNo, episodeNo uint16) (string, Episode) {
+	record := e.records[episodeNumber{seasonNo, episodeNo}]
+
+	return record.id, record.episode
+}
+
+func (e *episodeRecords) add(record episodeRecord) {
+	number := episodeNumber{record.episode.SeasonNo, record.episode.EpisodeNo}
+
+	current, ok := e.records[number]
+	if !ok {
+		e.records[number] = record
+
+		return
+	}
+
+	if isNewerRecord(record, current) {
+		e.records[number], record = record, current
+	}
+
+	e.duplicates = append(e.duplicates, record)
+}
+
+// isNewerRecord returns true if record a was written after b, comparing record IDs when both
+// were written at the same time so the same record is always picked.
+func isNewerRecord(a, b episodeRecord) bool {
+	if a.episode.Timestamp != b.episode.Timestamp {
+		return a.episode.Timestamp > b.episode.Timestamp
+	}
+
+	return a.id < b.id
+}
+
+func (estv ElasticTV) getEpisodeRecords(query *Query) (episodeRecords, error) {
+	result, err := estv.search(query, estv.Index.Episode, maxSyncEpisodes)
+	if err != nil {
+		return episodeRecords{}, err
+	}
+
+	episodes := episodeRecords{records: make(map[episodeNumber]episodeRecord, len(result.Hits.Hits))}
+
+	for _, hit := range result.Hits.Hits {
+		episode := Episode{}
+		if err := json.Unmarshal(hit.Source, &episode); err != nil {
+			return episodeRecords{}, fmt.Errorf("error parsing source: %w", err)
+		}
+
+		episodes.add(episodeRecord{id: hit.ID, episode: episode})
+	}
+
+	return episodes, nil
+}
+
+// isSameEpisode compares the indexed documents of episodes ignoring when they were written.
+func isSameEpisode(a, b Episode) bool {
+	a.Timestamp, b.Timestamp = "", ""
+	a.Provenance, b.Provenance = nil, nil
+
+	docA, errA := json.Marshal(a)
+	docB, errB := json.Marshal(b)
+
+	return errA == nil && errB == nil && bytes.Equal(docA, docB)
+}
@@ -0,0 +1,45 @@
+package elastictv
+
+import (
+	"testing"
+)
+
+func TestEpisodeRecords(t *testing.T) {
+	estv, _ := newTestElasticTV(t, func(request esRequest) string {
+		return `{"hits":{"total":{"value":4},"hits":[
+			{"_id":"old","_source":{"title":"Pilot (old)","season":1,"episode":1,"@timestamp":"2024-01-01T00:00:00.0000000"}},
+			{"_id":"new","_source":{"title":"Pilot","season":1,"episode":1,"@timestamp":"2025-01-01T00:00:00.0000000"}},
+			{"_id":"b","_source":{"title":"Cat's in the Bag (b)","season":1,"episode":2,"@timestamp":"2025-01-01T00:00:00.0000000"}},
+			{"_id":"a","_source":{"title":"Cat's in the Bag","season":1,"episode":2,"@timestamp":"2025-01-01T00:00:00.0000000"}}
+		]}}`
+	})
+
+	existing, err := estv.getEpisodeRecords(NewQuery())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		episodeNo uint16
+		wantID    string
+	}{
+		{1, "new"},
+		{2, "a"},
+		{3, ""},
+	}
+
+	for _, tt := range tests {
+		if recordID, _ := existing.find(1, tt.episodeNo); recordID != tt.wantID {
+			t.Errorf("find(1, %d) = %q, want %q", tt.episodeNo, recordID, tt.wantID)
+		}
+	}
+
+	duplicates := make(map[string]bool)
+	for _, duplicate := range existing.duplicates {
+		duplicates[duplicate.id] = true
+	}
+
+	if len(duplicates) != 2 || !duplicates["old"] || !duplicates["b"] {
+		t.Errorf("duplicates = %+v, want the records old and b", existing.duplicates)
+	}
+}
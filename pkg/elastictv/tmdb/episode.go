@@ -5,6 +5,7 @@ import (
 	"log"
 
 	"github.com/hashicorp/go-multierror"
+	"github.com/shaunschembri/go-tmdb"
 
 	"github.com/shaunschembri/elastictv/pkg/elastictv"
 )
@@ -18,11 +19,10 @@ func (t TMDb) SearchEpisode(searchItem elastictv.SearchItem) error {
 }
 
 func (t TMDb) searchEpisodeFromDetails(searchItem elastictv.SearchItem) error {
-	if searchItem.Attribute != elastictv.TMDbIDSearchAttribute &&
-		searchItem.Type != elastictv.EpisodeType &&
-		searchItem.SeasonNo > 0 &&
-		searchItem.EpisodeNo > 0 {
-		return nil
+	if searchItem.Attribute != elastictv.TMDbIDSearchAttribute ||
+		searchItem.Type != elastictv.EpisodeType ||
+		searchItem.EpisodeNo == 0 {
+		return elastictv.NewNotSupportedError(t.Name(), searchItem)
 	}
 
 	tmdbID, ok := searchItem.Query.(int)
@@ -40,31 +40,165 @@ func (t TMDb) searchEpisodeFromDetails(searchItem elastictv.SearchItem) error {
 		return fmt.Errorf("%s: error getting details for episode [ %s ] : %w", t.Name(), searchItem, err)
 	}
 
-	details := elastictv.Episode{
+	if err := t.estv.UpsertEpisode(t.getEpisode(*episode, tmdbID, options["language"])); err != nil {
+		return fmt.Errorf("%s: error indexing episode [ %s ] : %w", t.Name(), searchItem, err)
+	}
+
+	return nil
+}
+
+func (t TMDb) getEpisode(episode tmdb.TvEpisode, tvshowTMDbID int, language string) elastictv.Episode {
+	return elastictv.Episode{
 		AirDate: episode.AirDate,
 		TVShowIDs: elastictv.IDs{
-			TMDb: tmdbID,
+			TMDb: tvshowTMDbID,
 		},
-		Description: elastictv.Description{
-			Text:   episode.Overview,
-			Source: t.Name(),
-		},
-		EpisodeNo: uint16(episode.EpisodeNumber),
-		SeasonNo:  uint16(episode.SeasonNumber),
-		Image:     t.getImage(episode.StillPath),
+		Description: t.getDescription(episode.Overview, language),
+		EpisodeNo:   uint16(episode.EpisodeNumber),
+		SeasonNo:    uint16(episode.SeasonNumber),
+		Image:       t.getImage(episode.StillPath),
 		IDs: elastictv.IDs{
 			TMDb: episode.ID,
 			IMDb: episode.ExternalIDs.ImdbID,
+			TVDb: episode.ExternalIDs.TvdbID,
 		},
 		Rating: t.getRating(episode.VoteAverage),
 		Title:  episode.Name,
+		Source: t.Name(),
 	}
+}
 
-	if err := t.estv.UpsertEpisode(details); err != nil {
-		return fmt.Errorf("%s: error indexing episode [] %s ] : %w", t.Name(), searchItem, err)
+// SearchSeason gets a season with all its episodes in a single request. Episodes of a season
+// do not include their external IDs, which are added when an episode is looked up by itself.
+func (t TMDb) SearchSeason(searchItem elastictv.SearchItem) error {
+	if searchItem.Attribute != elastictv.TMDbIDSearchAttribute || searchItem.Type != elastictv.SeasonType {
+		return elastictv.NewNotSupportedError(t.Name(), searchItem)
 	}
 
-	return nil
+	tmdbID, ok := searchItem.Query.(int)
+	if !ok {
+		return fmt.Errorf("%s: cannot convert query item [ %s ] to TMDb ID", t.Name(), searchItem.Query)
+	}
+
+	season, episodes, err := t.getSeason(tmdbID, searchItem.SeasonNo)
+	if err != nil {
+		return err
+	}
+
+	var errors *multierror.Error
+
+	if err := t.estv.UpsertSeason(season); err != nil {
+		errors = multierror.Append(errors, fmt.Errorf("%s: error indexing season %d [ %s ] : %w",
+			t.Name(), searchItem.SeasonNo, searchItem, err))
+	}
+
+	if err := t.estv.BulkUpsertSeasonEpisodes(season.TVShowIDs, searchItem.SeasonNo, episodes); err != nil {
+		errors = multierror.Append(errors, fmt.Errorf("%s: error indexing episodes of season %d [ %s ] : %w",
+			t.Name(), searchItem.SeasonNo, searchItem, err))
+	}
+
+	return errors.ErrorOrNil()
+}
+
+// GetTVShowEpisodes gets all seasons of a tv show with their episodes.
+func (t TMDb) GetTVShowEpisodes(tvshowIDs elastictv.IDs) ([]elastictv.Season, []elastictv.Episode, error) {
+	if tvshowIDs.TMDb == 0 {
+		return nil, nil, fmt.Errorf("%s: tv show has no TMDb ID", t.Name())
+	}
+
+	list, err := t.GetSeasons(tvshowIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	seasons := make([]elastictv.Season, 0, len(list))
+	episodes := make([]elastictv.Episode, 0)
+
+	for _, s := range list {
+		season, seasonEpisodes, err := t.getSeason(tvshowIDs.TMDb, s.SeasonNo)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		seasons = append(seasons, season)
+		episodes = append(episodes, seasonEpisodes...)
+	}
+
+	return seasons, episodes, nil
+}
+
+// GetSeasons lists the seasons of a tv show as listed in its details, without their episodes.
+func (t TMDb) GetSeasons(tvshowIDs elastictv.IDs) ([]elastictv.Season, error) {
+	if tvshowIDs.TMDb == 0 {
+		return nil, fmt.Errorf("%s: tv show has no TMDb ID", t.Name())
+	}
+
+	log.Printf("%s: Getting seasons of tvshow [ %d ]", t.Name(), tvshowIDs.TMDb)
+
+	details, err := t.tmdb.GetTvInfo(tvshowIDs.TMDb, t.getDefaultOptions())
+	if err != nil {
+		return nil, fmt.Errorf("%s: error getting details for ID %d: %w", t.Name(), tvshowIDs.TMDb, err)
+	}
+
+	seasons := make([]elastictv.Season, 0, len(details.Seasons))
+	for _, season := range details.Seasons {
+		seasons = append(seasons, elastictv.Season{
+			Name:         season.Name,
+			AirDate:      season.AirDate,
+			EpisodeCount: season.EpisodeCount,
+			Image:        t.getImage(season.PosterPath),
+			SeasonNo:     uint16(season.SeasonNumber),
+			IDs: elastictv.IDs{
+				TMDb: season.ID,
+			},
+			TVShowIDs: elastictv.IDs{
+				TMDb: tvshowIDs.TMDb,
+			},
+			Source: t.Name(),
+		})
+	}
+
+	return seasons, nil
+}
+
+func (t TMDb) getSeason(tvshowTMDbID int, seasonNo uint16) (elastictv.Season, []elastictv.Episode, error) {
+	log.Printf("%s: Getting details for season %d of tvshow [ %d ]", t.Name(), seasonNo, tvshowTMDbID)
+
+	options := t.getDefaultOptions()
+	options["append_to_response"] = "external_ids"
+
+	season, err := t.tmdb.GetTvSeasonInfo(tvshowTMDbID, int(seasonNo), options)
+	if err != nil {
+		return elastictv.Season{}, nil, fmt.Errorf("%s: error getting details for season %d of tvshow [ %d ] : %w",
+			t.Name(), seasonNo, tvshowTMDbID, err)
+	}
+
+	details := elastictv.Season{
+		Name:         season.Name,
+		AirDate:      season.AirDate,
+		Description:  t.getDescription(season.Overview, options["language"]),
+		Image:        t.getImage(season.PosterPath),
+		EpisodeCount: len(season.Episodes),
+		SeasonNo:     uint16(season.SeasonNumber),
+		IDs: elastictv.IDs{
+			TMDb: season.ID,
+		},
+		TVShowIDs: elastictv.IDs{
+			TMDb: tvshowTMDbID,
+		},
+		Source: t.Name(),
+	}
+
+	if season.ExternalIDs != nil {
+		details.IDs.TVDb = season.ExternalIDs.TvdbID
+	}
+
+	episodes := make([]elastictv.Episode, 0, len(season.Episodes))
+	for _, episode := range season.Episodes {
+		episodes = append(episodes, t.getEpisode(episode, tvshowTMDbID, options["language"]))
+	}
+
+	return details, episodes, nil
 }
 
 func (t TMDb) searchEpisodeFromIMDbID(searchItem elastictv.SearchItem) error {
@@ -0,0 +1,139 @@
+package tmdb
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/shaunschembri/elastictv/pkg/elastictv"
+)
+
+// Episode groups are not supported by go-tmdb so they are requested directly from the API at
+// elastictv.provider.tmdb.base_url
+const (
+	defaultBaseURL           = "https://api.themoviedb.org/3"
+	apiRequestTimeout        = 30 * time.Second
+	absoluteEpisodeGroupType = 2
+)
+
+type episodeGroups struct {
+	Results []struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+		Type int    `json:"type"`
+	} `json:"results"`
+}
+
+type episodeGroup struct {
+	Groups []struct {
+		Order    int `json:"order"`
+		Episodes []struct {
+			Order         int `json:"order"`
+			SeasonNumber  int `json:"season_number"`
+			EpisodeNumber int `json:"episode_number"`
+		} `json:"episodes"`
+	} `json:"groups"`
+}
+
+// GetAbsoluteNumbering numbers the episodes of a tv show by its absolute episode group. Tv shows
+// without one are numbered in the order of their seasons, excluding specials.
+func (t TMDb) GetAbsoluteNumbering(tvshowIDs elastictv.IDs) ([]elastictv.Episode, error) {
+	if tvshowIDs.TMDb == 0 {
+		return nil, fmt.Errorf("%s: tv show has no TMDb ID", t.Name())
+	}
+
+	log.Printf("%s: Getting absolute numbering of tvshow [ %d ]", t.Name(), tvshowIDs.TMDb)
+
+	groups := episodeGroups{}
+	if err := t.get(fmt.Sprintf("/tv/%d/episode_groups", tvshowIDs.TMDb), &groups); err != nil {
+		return nil, fmt.Errorf("%s: error getting episode groups of tvshow [ %d ] : %w", t.Name(), tvshowIDs.TMDb, err)
+	}
+
+	for _, group := range groups.Results {
+		if group.Type == absoluteEpisodeGroupType {
+			return t.getEpisodeGroupNumbering(tvshowIDs, group.ID)
+		}
+	}
+
+	return t.getSeasonNumbering(tvshowIDs)
+}
+
+func (t TMDb) getEpisodeGroupNumbering(tvshowIDs elastictv.IDs, groupID string) ([]elastictv.Episode, error) {
+	group := episodeGroup{}
+	if err := t.get("/tv/episode_group/"+url.PathEscape(groupID), &group); err != nil {
+		return nil, fmt.Errorf("%s: error getting episode group [ %s ] : %w", t.Name(), groupID, err)
+	}
+
+	sort.SliceStable(group.Groups, func(i, j int) bool { return group.Groups[i].Order < group.Groups[j].Order })
+
+	episodes := make([]elastictv.Episode, 0)
+
+	for _, g := range group.Groups {
+		sort.SliceStable(g.Episodes, func(i, j int) bool { return g.Episodes[i].Order < g.Episodes[j].Order })
+
+		for _, episode := range g.Episodes {
+			episodes = append(episodes, elastictv.Episode{
+				TVShowIDs:  elastictv.IDs{TMDb: tvshowIDs.TMDb},
+				SeasonNo:   uint16(episode.SeasonNumber),
+				EpisodeNo:  uint16(episode.EpisodeNumber),
+				AbsoluteNo: uint16(len(episodes) + 1),
+				Source:     t.Name(),
+			})
+		}
+	}
+
+	return episodes, nil
+}
+
+func (t TMDb) getSeasonNumbering(tvshowIDs elastictv.IDs) ([]elastictv.Episode, error) {
+	seasons, err := t.GetSeasons(tvshowIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(seasons, func(i, j int) bool { return seasons[i].SeasonNo < seasons[j].SeasonNo })
+
+	episodes := make([]elastictv.Episode, 0)
+
+	for _, season := range seasons {
+		if season.SeasonNo == 0 {
+			continue
+		}
+
+		for episodeNo := 1; episodeNo <= season.EpisodeCount; episodeNo++ {
+			episodes = append(episodes, elastictv.Episode{
+				TVShowIDs:  elastictv.IDs{TMDb: tvshowIDs.TMDb},
+				SeasonNo:   season.SeasonNo,
+				EpisodeNo:  uint16(episodeNo),
+				AbsoluteNo: uint16(len(episodes) + 1),
+				Source:     t.Name(),
+			})
+		}
+	}
+
+	return episodes, nil
+}
+
+func (t TMDb) get(path string, result interface{}) error {
+	requestURL := t.baseURL + path + "?" + url.Values{"api_key": {t.apiKey}}.Encode()
+
+	response, err := t.httpClient.Get(requestURL)
+	if err != nil {
+		return fmt.Errorf("error requesting %s: %w", path, err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("request to %s returned status %s", path, response.Status)
+	}
+
+	if err := json.NewDecoder(response.Body).Decode(result); err != nil {
+		return fmt.Errorf("error parsing reply from %s: %w", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package tmdb
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/shaunschembri/elastictv/pkg/elastictv"
+)
+
+// newTestServer serves the fixtures in testdata for the episode group paths of the TMDb API.
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	fixtures := map[string]string{
+		"/tv/46260/episode_groups":                   "testdata/episode_groups.json",
+		"/tv/episode_group/5acf93e60e0a26346d0000ce": "testdata/episode_group.json",
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("api_key") != "key" {
+			http.Error(w, "invalid api key", http.StatusUnauthorized)
+
+			return
+		}
+
+		fixture, ok := fixtures[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		data, err := os.ReadFile(fixture)
+		if err != nil {
+			t.Error(err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(data)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func newTestTMDb(baseURL, apiKey string) TMDb {
+	return TMDb{
+		apiKey:     apiKey,
+		baseURL:    baseURL,
+		httpClient: &http.Client{Timeout: apiRequestTimeout},
+	}
+}
+
+func TestGetAbsoluteNumbering(t *testing.T) {
+	server := newTestServer(t)
+
+	episodes, err := newTestTMDb(server.URL, "key").GetAbsoluteNumbering(elastictv.IDs{TMDb: 46260})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []elastictv.Episode{
+		{TVShowIDs: elastictv.IDs{TMDb: 46260}, SeasonNo: 1, EpisodeNo: 1, AbsoluteNo: 1, Source: "TMDb"},
+		{TVShowIDs: elastictv.IDs{TMDb: 46260}, SeasonNo: 1, EpisodeNo: 2, AbsoluteNo: 2, Source: "TMDb"},
+		{TVShowIDs: elastictv.IDs{TMDb: 46260}, SeasonNo: 2, EpisodeNo: 1, AbsoluteNo: 3, Source: "TMDb"},
+	}
+
+	if !reflect.DeepEqual(episodes, want) {
+		t.Errorf("episodes = %+v, want %+v", episodes, want)
+	}
+}
+
+func TestGetAbsoluteNumberingErrors(t *testing.T) {
+	server := newTestServer(t)
+
+	if _, err := newTestTMDb(server.URL, "invalid").GetAbsoluteNumbering(elastictv.IDs{TMDb: 46260}); err == nil {
+		t.Error("expected an error with an invalid api key")
+	}
+
+	if _, err := newTestTMDb(server.URL, "key").GetAbsoluteNumbering(elastictv.IDs{}); err == nil {
+		t.Error("expected an error for a tv show without a TMDb ID")
+	}
+}
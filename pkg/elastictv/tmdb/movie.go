@@ -20,8 +20,17 @@ func (t TMDb) SearchMovies(params elastictv.SearchItem) error {
 		return t.searchMovieByActor(params.Query, params.Year)
 	case elastictv.IMDbIDSearchAttribute:
 		return t.searchMovieByIMDbID(params.Query)
+	case elastictv.TMDbIDSearchAttribute:
+		tmdbID, ok := params.Query.(int)
+		if !ok {
+			return fmt.Errorf("%s: cannot convert query item [ %s ] to TMDb ID", t.Name(), params.Query)
+		}
+
+		return t.getMovieDetails(tmdbID, "")
+	case elastictv.WikidataIDSearchAttribute:
+		return t.searchMovieByWikidataID(params.Query)
 	default:
-		return nil
+		return elastictv.NewNotSupportedError(t.Name(), params)
 	}
 }
 
@@ -50,6 +59,39 @@ func (t TMDb) searchMovieByIMDbID(movieID any) error {
 	return errors.ErrorOrNil()
 }
 
+// searchMovieByWikidataID finds a movie by its Wikidata ID, which is kept in the indexed
+// movie since TMDb does not list it in the movie details.
+func (t TMDb) searchMovieByWikidataID(movieID any) error {
+	wikidataID, ok := movieID.(string)
+	if !ok {
+		return fmt.Errorf("%s: cannot convert query item [ %s ] to Wikidata ID", t.Name(), movieID)
+	}
+
+	log.Printf("%s: Searching for movie by Wikidata ID [ %s ]", t.Name(), wikidataID)
+
+	findResults, err := t.tmdb.GetFind(wikidataID, "wikidata_id", nil)
+	if err != nil {
+		return fmt.Errorf("%s: error searching movie by Wikidata ID [ %s ]: %w", t.Name(), wikidataID, err)
+	}
+
+	var errors *multierror.Error
+
+	for _, movie := range findResults.MovieResults {
+		if err := t.getMovieDetails(movie.ID, movie.OriginalLanguage); err != nil {
+			errors = multierror.Append(errors, err)
+
+			continue
+		}
+
+		ids := elastictv.IDs{TMDb: movie.ID, Wikidata: wikidataID}
+		if err := t.estv.AddTitleIDs(elastictv.MovieType, ids); err != nil {
+			errors = multierror.Append(errors, err)
+		}
+	}
+
+	return errors.ErrorOrNil()
+}
+
 func (t TMDb) searchMovieByTitle(movieTitle any, year uint16) error {
 	title, ok := movieTitle.(string)
 	if !ok {
@@ -67,8 +109,13 @@ func (t TMDb) searchMovieByTitle(movieTitle any, year uint16) error {
 
 	var errors *multierror.Error
 
-	for _, movie := range movies.Results {
-		if t.getYear(movie.ReleaseDate) != year {
+	for i, movie := range movies.Results {
+		// Without a year only the best results are fetched since there is nothing to filter them by
+		if year == 0 && i >= maxResultsWithoutYear {
+			break
+		}
+
+		if !elastictv.IsMovieYearInRange(t.getYear(movie.ReleaseDate), year) {
 			continue
 		}
 
@@ -110,7 +157,7 @@ func (t TMDb) searchMovieByDirector(director any, year uint16) error {
 				continue
 			}
 
-			if t.getYear(credit.ReleaseDate) != year {
+			if !t.isCreditYearInRange(credit.ReleaseDate, year) {
 				continue
 			}
 
@@ -148,7 +195,7 @@ func (t TMDb) searchMovieByActor(actor any, year uint16) error {
 		}
 
 		for _, credit := range credits.Cast {
-			if t.getYear(credit.ReleaseDate) != year {
+			if !t.isCreditYearInRange(credit.ReleaseDate, year) {
 				continue
 			}
 
@@ -161,6 +208,12 @@ func (t TMDb) searchMovieByActor(actor any, year uint16) error {
 	return errors.ErrorOrNil()
 }
 
+// isCreditYearInRange reports whether a credit of a person matches the year of a search. Credits
+// are only searched with a year since people can have too many credits to fetch them all.
+func (t TMDb) isCreditYearInRange(releaseDate string, year uint16) bool {
+	return year > 0 && elastictv.IsMovieYearInRange(t.getYear(releaseDate), year)
+}
+
 func (t TMDb) getMovieDetails(tmdbID int, originalLanguage string) error {
 	query := elastictv.NewQuery().WithTMDbID(tmdbID).WithType(elastictv.MovieType)
 	if !t.estv.IsRecordExpired(query, t.estv.Index.Title) {
@@ -187,19 +240,17 @@ func (t TMDb) getMovieDetails(tmdbID int, originalLanguage string) error {
 			TMDb: details.ID,
 			IMDb: details.ImdbID,
 		},
-		Rating: t.getRating(details.VoteAverage),
-		Image:  t.getImage(details.PosterPath),
-		Description: elastictv.Description{
-			Text:   details.Overview,
-			Source: t.Name(),
-		},
-		Year:     year,
-		Tagline:  details.Tagline,
-		Country:  t.getCountries(details.ProductionCountries),
-		Language: t.getLanguage(details.SpokenLanguages),
-		Credits:  t.getCredits(details.Credits.Cast, details.Credits.Crew),
-		Alias:    t.getMovieAliases(*details.Translations, *details.AlternativeTitles, details.Title),
-		Type:     elastictv.MovieType,
+		Rating:      t.getRating(details.VoteAverage),
+		Image:       t.getImage(details.PosterPath),
+		Description: t.getDescription(details.Overview, options["language"]),
+		Year:        year,
+		Tagline:     details.Tagline,
+		Country:     t.getCountries(details.ProductionCountries),
+		Language:    t.getLanguage(details.SpokenLanguages),
+		Credits:     t.getCredits(details.Credits.Cast, details.Credits.Crew),
+		Alias:       t.getMovieAliases(*details.Translations, *details.AlternativeTitles, details.Title),
+		Type:        elastictv.MovieType,
+		Source:      t.Name(),
 	}
 
 	if err := t.estv.UpsertTitle(movie); err != nil {
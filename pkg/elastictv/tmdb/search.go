@@ -2,6 +2,7 @@ package tmdb
 
 import (
 	"fmt"
+	"net/http"
 	"strconv"
 	"strings"
 
@@ -17,13 +18,19 @@ const (
 	directorJob          = "Director"
 	producerJob          = "Producer"
 	executiveProducerJob = "Executive Producer"
+	// Number of results of a movie search without a year which are fetched
+	maxResultsWithoutYear = 5
 )
 
 type TMDb struct {
 	estv     *elastictv.ElasticTV
 	tmdb     *tmdb.TMDb
+	apiKey   string
 	language string
-	genre    map[int]string
+	// Base URL and client of the API requests which go-tmdb does not support
+	baseURL    string
+	httpClient *http.Client
+	genre      map[int]string
 	// List of country codes to use titles from
 	aliasCountryCodes []string
 	// List of language codes to use title and description in original language.
@@ -37,9 +44,16 @@ func (t TMDb) Name() string {
 }
 
 func (t TMDb) Init(estv *elastictv.ElasticTV) (elastictv.SearchableProvider, error) {
+	t.apiKey = viper.GetString("elastictv.provider.tmdb.api_key")
 	t.tmdb = tmdb.Init(tmdb.Config{
-		APIKey: viper.GetString("elastictv.provider.tmdb.api_key"),
+		APIKey: t.apiKey,
 	})
+	t.baseURL = strings.TrimRight(viper.GetString("elastictv.provider.tmdb.base_url"), "/")
+	if t.baseURL == "" {
+		t.baseURL = defaultBaseURL
+	}
+
+	t.httpClient = &http.Client{Timeout: apiRequestTimeout}
 	t.language = viper.GetString("elastictv.provider.tmdb.language")
 	t.aliasCountryCodes = viper.GetStringSlice("elastictv.provider.tmdb.alias_countries")
 	t.originalLanguageCodes = viper.GetStringSlice("elastictv.provider.tmdb.keep_original_title_desc")
@@ -59,6 +73,26 @@ func (t TMDb) Init(estv *elastictv.ElasticTV) (elastictv.SearchableProvider, err
 	return t, nil
 }
 
+func (t TMDb) Capabilities() elastictv.Capabilities {
+	return elastictv.Capabilities{
+		{Type: elastictv.MovieType, Attributes: []elastictv.SearchAttribute{
+			elastictv.TitleSearchAttribute, elastictv.DirectorSearchAttribute, elastictv.ActorSearchAttribute,
+			elastictv.IMDbIDSearchAttribute, elastictv.TMDbIDSearchAttribute, elastictv.WikidataIDSearchAttribute,
+		}},
+		{Type: elastictv.TvShowType, Attributes: []elastictv.SearchAttribute{
+			elastictv.TitleSearchAttribute, elastictv.DirectorSearchAttribute, elastictv.ActorSearchAttribute,
+			elastictv.IMDbIDSearchAttribute, elastictv.TMDbIDSearchAttribute, elastictv.TVDbIDSearchAttribute,
+			elastictv.WikidataIDSearchAttribute,
+		}},
+		{Type: elastictv.EpisodeType, Attributes: []elastictv.SearchAttribute{
+			elastictv.IMDbIDSearchAttribute, elastictv.TMDbIDSearchAttribute,
+		}},
+		{Type: elastictv.SeasonType, Attributes: []elastictv.SearchAttribute{
+			elastictv.TMDbIDSearchAttribute,
+		}},
+	}
+}
+
 func (t TMDb) getDefaultOptions() map[string]string {
 	return map[string]string{
 		"language": t.language,
@@ -188,12 +222,27 @@ func (t TMDb) getCredits(cast tmdb.CastCredit, crew tmdb.CrewCredit) elastictv.C
 	return credits
 }
 
-func (t TMDb) getRating(rating float32) *elastictv.Rating {
+func (t TMDb) getDescription(overview, language string) elastictv.Descriptions {
+	if overview == "" {
+		return nil
+	}
+
+	// Keep only the ISO 639-1 code of languages like en-US
+	language, _, _ = strings.Cut(language, "-")
+
+	return elastictv.Descriptions{{
+		Text:     overview,
+		Language: language,
+		Source:   t.Name(),
+	}}
+}
+
+func (t TMDb) getRating(rating float32) elastictv.Ratings {
 	if rating > 0 {
-		return &elastictv.Rating{
+		return elastictv.Ratings{{
 			Value:  rating,
 			Source: t.Name(),
-		}
+		}}
 	}
 
 	return nil
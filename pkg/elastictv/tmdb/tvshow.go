@@ -18,13 +18,50 @@ func (t TMDb) SearchTvShows(params elastictv.SearchItem) error {
 		return t.searchTVShowByDirector(params.Query)
 	case elastictv.ActorSearchAttribute:
 		return t.searchTVShowByActor(params.Query)
+	case elastictv.IMDbIDSearchAttribute:
+		return t.searchTVShowByExternalID(params.Query, "imdb_id")
 	case elastictv.TMDbIDSearchAttribute:
 		return t.getTVShowDetails(params.Query)
+	case elastictv.TVDbIDSearchAttribute:
+		return t.searchTVShowByExternalID(params.Query, "tvdb_id")
+	case elastictv.WikidataIDSearchAttribute:
+		return t.searchTVShowByExternalID(params.Query, "wikidata_id")
 	default:
-		return nil
+		return elastictv.NewNotSupportedError(t.Name(), params)
 	}
 }
 
+func (t TMDb) searchTVShowByExternalID(externalID any, source string) error {
+	id := fmt.Sprintf("%v", externalID)
+
+	log.Printf("%s: Searching for tvshow by %s [ %s ]", t.Name(), source, id)
+
+	findResults, err := t.tmdb.GetFind(id, source, nil)
+	if err != nil {
+		return fmt.Errorf("%s: error searching tvshow by %s [ %s ]: %w", t.Name(), source, id, err)
+	}
+
+	var errors *multierror.Error
+
+	for _, tvshow := range findResults.TvResults {
+		if err := t.getTVShowDetails(tvshow.ID); err != nil {
+			errors = multierror.Append(errors, err)
+
+			continue
+		}
+
+		// TMDb does not list Wikidata IDs in the tv show details so keep the one looked up
+		if source == "wikidata_id" {
+			ids := elastictv.IDs{TMDb: tvshow.ID, Wikidata: id}
+			if err := t.estv.AddTitleIDs(elastictv.TvShowType, ids); err != nil {
+				errors = multierror.Append(errors, err)
+			}
+		}
+	}
+
+	return errors.ErrorOrNil()
+}
+
 func (t TMDb) searchTVShowByTitle(tvshowTitle any) error {
 	title, ok := tvshowTitle.(string)
 	if !ok {
@@ -160,19 +197,18 @@ func (t TMDb) getTVShowDetails(tvShowID any, originalLanguage ...string) error {
 		IDs: elastictv.IDs{
 			TMDb: details.ID,
 			IMDb: details.ExternalIDs.ImdbID,
+			TVDb: details.ExternalIDs.TvdbID,
 		},
-		Rating: t.getRating(details.VoteAverage),
-		Image:  t.getImage(details.PosterPath),
-		Description: elastictv.Description{
-			Text:   details.Overview,
-			Source: t.Name(),
-		},
-		Year:     t.getYear(details.FirstAirDate),
-		Country:  t.getCountries(details.ProductionCountries),
-		Language: t.getLanguage(details.SpokenLanguages),
-		Credits:  t.getCredits(details.Credits.Cast, details.Credits.Crew),
-		Alias:    t.getTVAliases(*details.Translations, *details.AlternativeTitles, details.Name),
-		Type:     elastictv.TvShowType,
+		Rating:      t.getRating(details.VoteAverage),
+		Image:       t.getImage(details.PosterPath),
+		Description: t.getDescription(details.Overview, options["language"]),
+		Year:        t.getYear(details.FirstAirDate),
+		Country:     t.getCountries(details.ProductionCountries),
+		Language:    t.getLanguage(details.SpokenLanguages),
+		Credits:     t.getCredits(details.Credits.Cast, details.Credits.Crew),
+		Alias:       t.getTVAliases(*details.Translations, *details.AlternativeTitles, details.Name),
+		Type:        elastictv.TvShowType,
+		Source:      t.Name(),
 	}
 
 	if err := t.estv.UpsertTitle(tvshow); err != nil {
@@ -0,0 +1,135 @@
+package trakt
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultBaseURL  = "https://api.trakt.tv"
+	apiVersion      = "2"
+	requestTimeout  = 30 * time.Second
+	maxRetries      = 5
+	defaultMaxPages = 1
+	pageLimit       = 10
+)
+
+var errNotFound = errors.New("not found")
+
+type client struct {
+	baseURL    string
+	clientID   string
+	maxPages   int
+	httpClient *http.Client
+}
+
+func newClient(baseURL, clientID string, maxPages int) client {
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
+
+	if maxPages == 0 {
+		maxPages = defaultMaxPages
+	}
+
+	return client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		clientID:   clientID,
+		maxPages:   maxPages,
+		httpClient: &http.Client{Timeout: requestTimeout},
+	}
+}
+
+// get requests the given path and decodes the JSON reply into result. It returns the total
+// number of pages as reported by the pagination headers of Trakt.
+func (c client) get(path string, params url.Values, result interface{}) (int, error) {
+	requestURL := c.baseURL + path
+	if len(params) > 0 {
+		requestURL += "?" + params.Encode()
+	}
+
+	request, err := http.NewRequest(http.MethodGet, requestURL, nil)
+	if err != nil {
+		return 0, fmt.Errorf("error creating request for %s: %w", requestURL, err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("trakt-api-version", apiVersion)
+	request.Header.Set("trakt-api-key", c.clientID)
+
+	for retry := 0; ; retry++ {
+		response, err := c.httpClient.Do(request)
+		if err != nil {
+			return 0, fmt.Errorf("error requesting %s: %w", requestURL, err)
+		}
+
+		if response.StatusCode == http.StatusTooManyRequests && retry < maxRetries {
+			response.Body.Close()
+			time.Sleep(c.getRetryAfter(response))
+
+			continue
+		}
+
+		defer response.Body.Close()
+
+		switch {
+		case response.StatusCode == http.StatusNotFound:
+			return 0, errNotFound
+		case response.StatusCode != http.StatusOK:
+			return 0, fmt.Errorf("request to %s returned status %s", requestURL, response.Status)
+		}
+
+		if err := json.NewDecoder(response.Body).Decode(result); err != nil {
+			return 0, fmt.Errorf("error parsing reply from %s: %w", requestURL, err)
+		}
+
+		pageCount, _ := strconv.Atoi(response.Header.Get("X-Pagination-Page-Count"))
+
+		return pageCount, nil
+	}
+}
+
+func (c client) getRetryAfter(response *http.Response) time.Duration {
+	seconds, err := strconv.Atoi(response.Header.Get("Retry-After"))
+	if err != nil || seconds <= 0 {
+		return time.Second
+	}
+
+	return time.Duration(seconds) * time.Second
+}
+
+// getPaged requests every page of a paginated list up to the configured maximum number of pages.
+func getPaged[T any](c client, path string, params url.Values) ([]T, error) {
+	results := make([]T, 0)
+
+	for page := 1; page <= c.maxPages; page++ {
+		pageParams := url.Values{}
+		for key, values := range params {
+			pageParams[key] = values
+		}
+
+		pageParams.Set("page", strconv.Itoa(page))
+		pageParams.Set("limit", strconv.Itoa(pageLimit))
+
+		pageResults := make([]T, 0)
+
+		pageCount, err := c.get(path, pageParams, &pageResults)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, pageResults...)
+
+		if page >= pageCount {
+			break
+		}
+	}
+
+	return results, nil
+}
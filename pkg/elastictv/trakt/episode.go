@@ -0,0 +1,145 @@
+package trakt
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/shaunschembri/elastictv/pkg/elastictv"
+)
+
+const airDateFormat = "2006-01-02"
+
+func (t Trakt) SearchEpisode(searchItem elastictv.SearchItem) error {
+	if searchItem.Attribute == elastictv.IMDbIDSearchAttribute {
+		return t.searchEpisodeFromIMDbID(searchItem)
+	}
+
+	return t.searchEpisodeFromDetails(searchItem)
+}
+
+func (t Trakt) searchEpisodeFromDetails(searchItem elastictv.SearchItem) error {
+	if searchItem.Attribute != elastictv.TMDbIDSearchAttribute ||
+		searchItem.Type != elastictv.EpisodeType ||
+		searchItem.EpisodeNo == 0 {
+		return elastictv.NewNotSupportedError(t.Name(), searchItem)
+	}
+
+	results, err := t.lookup("tmdb", showMediaType, searchItem.Query)
+	if err != nil {
+		return fmt.Errorf("%s: error searching tvshow for episode [ %s ] : %w", t.Name(), searchItem, err)
+	}
+
+	var searchErrors *multierror.Error
+
+	for _, result := range results {
+		if result.Show == nil {
+			continue
+		}
+
+		err := t.getEpisodeDetails(*result.Show, int(searchItem.SeasonNo), int(searchItem.EpisodeNo))
+		if err != nil {
+			searchErrors = multierror.Append(searchErrors, err)
+		}
+	}
+
+	return searchErrors.ErrorOrNil()
+}
+
+func (t Trakt) searchEpisodeFromIMDbID(searchItem elastictv.SearchItem) error {
+	log.Printf("%s: Searching for episode [ %s ]", t.Name(), searchItem)
+
+	results, err := t.lookup("imdb", episodeMediaType, searchItem.Query)
+	if err != nil {
+		return fmt.Errorf("%s: error searching for episode [ %s ] : %w", t.Name(), searchItem, err)
+	}
+
+	var searchErrors *multierror.Error
+
+	for _, result := range results {
+		if result.Show == nil || result.Episode == nil {
+			continue
+		}
+
+		if err := t.getEpisodeDetails(*result.Show, result.Episode.Season, result.Episode.Number); err != nil {
+			searchErrors = multierror.Append(searchErrors, err)
+		}
+	}
+
+	return searchErrors.ErrorOrNil()
+}
+
+func (t Trakt) getEpisodeDetails(tvshow show, seasonNo, episodeNo int) error {
+	log.Printf("%s: Getting details for episode [ %s S%02dE%02d ]", t.Name(), tvshow.Title, seasonNo, episodeNo)
+
+	episode, err := t.getEpisode(tvshow, seasonNo, episodeNo)
+	if err != nil {
+		return fmt.Errorf("%s: error getting details for episode [ %s S%02dE%02d ] : %w",
+			t.Name(), tvshow.Title, seasonNo, episodeNo, err)
+	}
+
+	if episode == nil {
+		return nil
+	}
+
+	if err := t.estv.UpsertEpisode(*episode); err != nil {
+		return fmt.Errorf("%s: error indexing episode [ %s S%02dE%02d ] : %w",
+			t.Name(), tvshow.Title, seasonNo, episodeNo, err)
+	}
+
+	return nil
+}
+
+// getEpisode gets an episode of a tv show by its season and episode number, returning nil if
+// Trakt does not list the episode.
+func (t Trakt) getEpisode(tvshow show, seasonNo, episodeNo int) (*elastictv.Episode, error) {
+	details := episode{}
+	path := fmt.Sprintf("/shows/%d/seasons/%d/episodes/%d", tvshow.IDs.Trakt, seasonNo, episodeNo)
+
+	_, err := t.client.get(path, url.Values{"extended": {"full"}}, &details)
+	if errors.Is(err, errNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &elastictv.Episode{
+		AirDate:     getAirDate(details.FirstAired, tvshow.Airs.Timezone),
+		TVShowIDs:   t.getIDs(tvshow.IDs),
+		Description: t.getDescription(details.Overview),
+		EpisodeNo:   uint16(details.Number),
+		SeasonNo:    uint16(details.Season),
+		AbsoluteNo:  uint16(details.NumberAbs),
+		IDs:         t.getIDs(details.IDs),
+		Rating:      t.getRating(details.Rating),
+		Title:       details.Title,
+		Source:      t.Name(),
+	}, nil
+}
+
+// getAirDate returns the date an episode first aired in the timezone of its tv show, as Trakt
+// only gives the UTC time. The date is left empty when the timezone is not known, since the UTC
+// date of evening airings in the Americas is the day after.
+func getAirDate(firstAired, timezone string) string {
+	if firstAired == "" || timezone == "" {
+		return ""
+	}
+
+	airedAt, err := time.Parse(time.RFC3339, firstAired)
+	if err != nil {
+		return ""
+	}
+
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return ""
+	}
+
+	return airedAt.In(location).Format(airDateFormat)
+}
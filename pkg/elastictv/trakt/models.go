@@ -0,0 +1,85 @@
+package trakt
+
+type ids struct {
+	Trakt int    `json:"trakt"`
+	Slug  string `json:"slug"`
+	TVDb  int    `json:"tvdb"`
+	IMDb  string `json:"imdb"`
+	TMDb  int    `json:"tmdb"`
+}
+
+type movie struct {
+	Title    string   `json:"title"`
+	Year     int      `json:"year"`
+	IDs      ids      `json:"ids"`
+	Tagline  string   `json:"tagline"`
+	Overview string   `json:"overview"`
+	Rating   float32  `json:"rating"`
+	Genres   []string `json:"genres"`
+}
+
+type show struct {
+	Title    string   `json:"title"`
+	Year     int      `json:"year"`
+	IDs      ids      `json:"ids"`
+	Overview string   `json:"overview"`
+	Rating   float32  `json:"rating"`
+	Genres   []string `json:"genres"`
+	Airs     struct {
+		Timezone string `json:"timezone"`
+	} `json:"airs"`
+}
+
+type episode struct {
+	Season     int     `json:"season"`
+	Number     int     `json:"number"`
+	NumberAbs  int     `json:"number_abs"`
+	Title      string  `json:"title"`
+	IDs        ids     `json:"ids"`
+	Overview   string  `json:"overview"`
+	Rating     float32 `json:"rating"`
+	FirstAired string  `json:"first_aired"`
+}
+
+type person struct {
+	Name string `json:"name"`
+	IDs  ids    `json:"ids"`
+}
+
+type searchResult struct {
+	Type    string   `json:"type"`
+	Score   float64  `json:"score"`
+	Movie   *movie   `json:"movie"`
+	Show    *show    `json:"show"`
+	Episode *episode `json:"episode"`
+	Person  *person  `json:"person"`
+}
+
+type alias struct {
+	Title   string `json:"title"`
+	Country string `json:"country"`
+}
+
+type people struct {
+	Cast []struct {
+		Person person `json:"person"`
+	} `json:"cast"`
+	Crew map[string][]struct {
+		Job    string   `json:"job"`
+		Jobs   []string `json:"jobs"`
+		Person person   `json:"person"`
+	} `json:"crew"`
+}
+
+type personCredits struct {
+	Cast []struct {
+		Movie *movie `json:"movie"`
+		Show  *show  `json:"show"`
+	} `json:"cast"`
+	Crew map[string][]struct {
+		Job   string   `json:"job"`
+		Jobs  []string `json:"jobs"`
+		Movie *movie   `json:"movie"`
+		Show  *show    `json:"show"`
+	} `json:"crew"`
+}
@@ -0,0 +1,139 @@
+package trakt
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/shaunschembri/elastictv/pkg/elastictv"
+)
+
+func (t Trakt) SearchMovies(params elastictv.SearchItem) error {
+	switch params.Attribute {
+	case elastictv.TitleSearchAttribute:
+		return t.searchMovieByTitle(params.Query, params.Year)
+	case elastictv.DirectorSearchAttribute:
+		return t.searchMovieByCredits(params.Query, true, params.Year)
+	case elastictv.ActorSearchAttribute:
+		return t.searchMovieByCredits(params.Query, false, params.Year)
+	case elastictv.IMDbIDSearchAttribute:
+		return t.searchMovieByExternalID("imdb", params.Query)
+	case elastictv.TMDbIDSearchAttribute:
+		return t.searchMovieByExternalID("tmdb", params.Query)
+	case elastictv.TraktIDSearchAttribute:
+		return t.searchMovieByExternalID("trakt", params.Query)
+	default:
+		return elastictv.NewNotSupportedError(t.Name(), params)
+	}
+}
+
+func (t Trakt) searchMovieByTitle(movieTitle any, year uint16) error {
+	title, ok := movieTitle.(string)
+	if !ok {
+		return fmt.Errorf("%s: cannot convert query item [ %s ] to movie title", t.Name(), movieTitle)
+	}
+
+	log.Printf("%s: Searching for movie by title [ %s | Year: %d ]", t.Name(), title, year)
+
+	traktIDs, err := t.search(movieMediaType, title, year)
+	if err != nil {
+		return fmt.Errorf("%s: error searching movie title [ %s ]: %w", t.Name(), title, err)
+	}
+
+	return t.getMoviesDetails(traktIDs)
+}
+
+func (t Trakt) searchMovieByCredits(personName any, director bool, year uint16) error {
+	name, ok := personName.(string)
+	if !ok {
+		return fmt.Errorf("%s: cannot convert query item [ %s ] to person name", t.Name(), personName)
+	}
+
+	log.Printf("%s: Searching for movie credits [ %s | Year: %d ]", t.Name(), name, year)
+
+	traktIDs, err := t.searchByCredits(movieMediaType, name, director, year)
+	if err != nil {
+		return err
+	}
+
+	return t.getMoviesDetails(traktIDs)
+}
+
+func (t Trakt) searchMovieByExternalID(source string, externalID any) error {
+	log.Printf("%s: Searching for movie by %s ID [ %v ]", t.Name(), source, externalID)
+
+	results, err := t.lookup(source, movieMediaType, externalID)
+	if err != nil {
+		return fmt.Errorf("%s: error searching movie by %s ID [ %v ]: %w", t.Name(), source, externalID, err)
+	}
+
+	traktIDs := make([]int, 0)
+	for _, result := range results {
+		if result.Movie != nil {
+			traktIDs = append(traktIDs, result.Movie.IDs.Trakt)
+		}
+	}
+
+	return t.getMoviesDetails(traktIDs)
+}
+
+func (t Trakt) getMoviesDetails(traktIDs []int) error {
+	var errors *multierror.Error
+
+	for _, traktID := range traktIDs {
+		if err := t.getMovieDetails(traktID); err != nil {
+			errors = multierror.Append(errors, err)
+		}
+	}
+
+	return errors.ErrorOrNil()
+}
+
+func (t Trakt) getMovieDetails(traktID int) error {
+	query := elastictv.NewQuery().WithTraktID(traktID).WithType(elastictv.MovieType)
+	if !t.estv.IsRecordExpired(query, t.estv.Index.Title) {
+		return nil
+	}
+
+	movie, err := t.getMovie(traktID)
+	if err != nil {
+		return err
+	}
+
+	if err := t.estv.UpsertTitle(movie); err != nil {
+		return fmt.Errorf("error indexing movie: %w", err)
+	}
+
+	return nil
+}
+
+// getMovie gets the details of a movie with its credits and aliases.
+func (t Trakt) getMovie(traktID int) (elastictv.Title, error) {
+	details := movie{}
+	if _, err := t.client.get(fmt.Sprintf("/movies/%d", traktID), url.Values{"extended": {"full"}}, &details); err != nil {
+		return elastictv.Title{}, fmt.Errorf("%s: error getting details for ID %d: %w", t.Name(), traktID, err)
+	}
+
+	log.Printf("%s: Got details for movie [ %s | Year: %d ]", t.Name(), details.Title, details.Year)
+
+	credits, err := t.getPeople(movieMediaType, traktID)
+	if err != nil {
+		return elastictv.Title{}, err
+	}
+
+	return elastictv.Title{
+		Title:       details.Title,
+		Genre:       t.getGenres(details.Genres),
+		IDs:         t.getIDs(details.IDs),
+		Rating:      t.getRating(details.Rating),
+		Description: t.getDescription(details.Overview),
+		Year:        uint16(details.Year),
+		Tagline:     details.Tagline,
+		Credits:     credits,
+		Alias:       t.getAliases(movieMediaType, traktID, details.Title),
+		Type:        elastictv.MovieType,
+		Source:      t.Name(),
+	}, nil
+}
@@ -0,0 +1,307 @@
+package trakt
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/spf13/viper"
+
+	"github.com/shaunschembri/elastictv/pkg/elastictv"
+)
+
+const (
+	// Trakt returns overviews in English unless translations are requested
+	descriptionLanguage  = "en"
+	maxActors            = 10
+	maxOtherCredits      = 5
+	directorJob          = "Director"
+	producerJob          = "Producer"
+	executiveProducerJob = "Executive Producer"
+	movieMediaType       = "movie"
+	showMediaType        = "show"
+	episodeMediaType     = "episode"
+)
+
+type Trakt struct {
+	estv   *elastictv.ElasticTV
+	client client
+	// List of country codes to use titles from
+	aliasCountryCodes []string
+}
+
+func (t Trakt) Name() string {
+	return "Trakt"
+}
+
+func (t Trakt) Init(estv *elastictv.ElasticTV) (elastictv.SearchableProvider, error) {
+	clientID := viper.GetString("elastictv.provider.trakt.client_id")
+	if clientID == "" {
+		return nil, errors.New("trakt client id is not set")
+	}
+
+	t.client = newClient(
+		viper.GetString("elastictv.provider.trakt.base_url"),
+		clientID,
+		viper.GetInt("elastictv.provider.trakt.max_pages"),
+	)
+	t.aliasCountryCodes = viper.GetStringSlice("elastictv.provider.trakt.alias_countries")
+	t.estv = estv
+
+	return t, nil
+}
+
+func (t Trakt) Capabilities() elastictv.Capabilities {
+	return elastictv.Capabilities{
+		{Type: elastictv.MovieType, Attributes: []elastictv.SearchAttribute{
+			elastictv.TitleSearchAttribute, elastictv.DirectorSearchAttribute, elastictv.ActorSearchAttribute,
+			elastictv.IMDbIDSearchAttribute, elastictv.TMDbIDSearchAttribute, elastictv.TraktIDSearchAttribute,
+		}},
+		{Type: elastictv.TvShowType, Attributes: []elastictv.SearchAttribute{
+			elastictv.TitleSearchAttribute, elastictv.DirectorSearchAttribute, elastictv.ActorSearchAttribute,
+			elastictv.IMDbIDSearchAttribute, elastictv.TMDbIDSearchAttribute, elastictv.TVDbIDSearchAttribute,
+			elastictv.TraktIDSearchAttribute,
+		}},
+		{Type: elastictv.EpisodeType, Attributes: []elastictv.SearchAttribute{
+			elastictv.IMDbIDSearchAttribute, elastictv.TMDbIDSearchAttribute,
+		}},
+	}
+}
+
+// search runs a text search for the given media type and returns the Trakt IDs of the results.
+func (t Trakt) search(mediaType, query string, year uint16) ([]int, error) {
+	params := url.Values{"query": {query}}
+
+	switch {
+	case year > 0 && mediaType == movieMediaType:
+		tolerance := elastictv.MovieYearTolerance()
+		params.Set("years", fmt.Sprintf("%d-%d", year-min(year, tolerance), int(year)+int(tolerance)))
+	case year > 0:
+		params.Set("years", fmt.Sprintf("%d", year))
+	}
+
+	results, err := getPaged[searchResult](t.client, "/search/"+mediaType, params)
+	if err != nil {
+		return nil, err
+	}
+
+	traktIDs := make([]int, 0)
+
+	for _, result := range results {
+		if id := result.getIDs().Trakt; id > 0 {
+			traktIDs = append(traktIDs, id)
+		}
+	}
+
+	return traktIDs, nil
+}
+
+// lookup finds the Trakt IDs of titles having the given external ID. Source can be one of
+// imdb, tmdb or tvdb.
+func (t Trakt) lookup(source, mediaType string, externalID any) ([]searchResult, error) {
+	path := fmt.Sprintf("/search/%s/%s", source, url.PathEscape(fmt.Sprintf("%v", externalID)))
+
+	results := make([]searchResult, 0)
+
+	_, err := t.client.get(path, url.Values{"type": {mediaType}, "extended": {"full"}}, &results)
+	if errors.Is(err, errNotFound) {
+		return results, nil
+	}
+
+	return results, err
+}
+
+// searchByCredits searches for a person and returns the Trakt IDs of the titles the person
+// has credits on. If director is true only directing credits are considered.
+func (t Trakt) searchByCredits(mediaType, name string, director bool, year uint16) ([]int, error) {
+	persons, err := getPaged[searchResult](t.client, "/search/person", url.Values{"query": {name}})
+	if err != nil {
+		return nil, fmt.Errorf("%s: error searching for person [ %s ]: %w", t.Name(), name, err)
+	}
+
+	traktIDs := make([]int, 0)
+
+	var searchErrors *multierror.Error
+
+	for _, result := range persons {
+		if result.Person == nil {
+			continue
+		}
+
+		credits := personCredits{}
+		path := fmt.Sprintf("/people/%d/%ss", result.Person.IDs.Trakt, mediaType)
+
+		if _, err := t.client.get(path, nil, &credits); err != nil {
+			searchErrors = multierror.Append(searchErrors,
+				fmt.Errorf("%s: error searching credits for person [ %s ]: %w", t.Name(), result.Person.Name, err))
+
+			continue
+		}
+
+		if !director {
+			for _, credit := range credits.Cast {
+				traktIDs = t.appendCreditID(traktIDs, credit.Movie, credit.Show, year)
+			}
+
+			continue
+		}
+
+		for _, credit := range credits.Crew["directing"] {
+			if t.hasJob(credit.Job, credit.Jobs, directorJob) {
+				traktIDs = t.appendCreditID(traktIDs, credit.Movie, credit.Show, year)
+			}
+		}
+	}
+
+	return traktIDs, searchErrors.ErrorOrNil()
+}
+
+func (t Trakt) appendCreditID(traktIDs []int, m *movie, s *show, year uint16) []int {
+	switch {
+	case m != nil && elastictv.IsMovieYearInRange(uint16(m.Year), year):
+		return append(traktIDs, m.IDs.Trakt)
+	case s != nil:
+		return append(traktIDs, s.IDs.Trakt)
+	default:
+		return traktIDs
+	}
+}
+
+func (t Trakt) getPeople(mediaType string, traktID int) (elastictv.Credits, error) {
+	credits := elastictv.Credits{}
+	p := people{}
+
+	if _, err := t.client.get(fmt.Sprintf("/%ss/%d/people", mediaType, traktID), nil, &p); err != nil {
+		return credits, fmt.Errorf("%s: error getting credits for ID %d: %w", t.Name(), traktID, err)
+	}
+
+	for _, cast := range p.Cast {
+		if len(credits.Actor) < maxActors {
+			credits.Actor = append(credits.Actor, cast.Person.Name)
+		}
+	}
+
+	for _, crew := range p.Crew["directing"] {
+		if t.hasJob(crew.Job, crew.Jobs, directorJob) {
+			credits.Director = append(credits.Director, crew.Person.Name)
+		}
+	}
+
+	for _, crew := range p.Crew["production"] {
+		if len(credits.Other) < maxOtherCredits && t.hasJob(crew.Job, crew.Jobs, producerJob, executiveProducerJob) {
+			credits.Other = append(credits.Other, crew.Person.Name)
+		}
+	}
+
+	return credits, nil
+}
+
+func (t Trakt) getAliases(mediaType string, traktID int, title string) []string {
+	aliases := make([]string, 0)
+	list := make([]alias, 0)
+
+	if _, err := t.client.get(fmt.Sprintf("/%ss/%d/aliases", mediaType, traktID), nil, &list); err != nil {
+		log.Printf("%s: error getting aliases for ID %d: %s", t.Name(), traktID, err)
+
+		return aliases
+	}
+
+	for _, a := range list {
+		if a.Title == "" || !t.contains(t.aliasCountryCodes, a.Country) {
+			continue
+		}
+
+		if strings.EqualFold(title, a.Title) || t.contains(aliases, a.Title) {
+			continue
+		}
+
+		aliases = append(aliases, a.Title)
+	}
+
+	return aliases
+}
+
+func (t Trakt) getIDs(traktIDs ids) elastictv.IDs {
+	return elastictv.IDs{
+		Trakt:     traktIDs.Trakt,
+		TraktSlug: traktIDs.Slug,
+		TMDb:      traktIDs.TMDb,
+		IMDb:      traktIDs.IMDb,
+		TVDb:      traktIDs.TVDb,
+	}
+}
+
+// getGenres converts genre slugs (ex science-fiction) to names (ex Science Fiction).
+func (t Trakt) getGenres(slugs []string) []string {
+	genres := make([]string, 0)
+
+	for _, slug := range slugs {
+		words := strings.Split(slug, "-")
+		for i, word := range words {
+			if word != "" {
+				words[i] = strings.ToUpper(word[:1]) + word[1:]
+			}
+		}
+
+		genres = append(genres, strings.Join(words, " "))
+	}
+
+	return genres
+}
+
+func (t Trakt) getDescription(overview string) elastictv.Descriptions {
+	if overview == "" {
+		return nil
+	}
+
+	return elastictv.Descriptions{{
+		Text:     overview,
+		Language: descriptionLanguage,
+		Source:   t.Name(),
+	}}
+}
+
+func (t Trakt) getRating(rating float32) elastictv.Ratings {
+	if rating > 0 {
+		return elastictv.Ratings{{
+			Value:  rating,
+			Source: t.Name(),
+		}}
+	}
+
+	return nil
+}
+
+func (t Trakt) hasJob(job string, jobs []string, required ...string) bool {
+	for _, r := range required {
+		if job == r || t.contains(jobs, r) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (t Trakt) contains(stringSlice []string, value string) bool {
+	for _, n := range stringSlice {
+		if strings.EqualFold(n, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r searchResult) getIDs() ids {
+	switch {
+	case r.Movie != nil:
+		return r.Movie.IDs
+	case r.Show != nil:
+		return r.Show.IDs
+	default:
+		return ids{}
+	}
+}
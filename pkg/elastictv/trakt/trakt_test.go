@@ -0,0 +1,255 @@
+package trakt
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/shaunschembri/elastictv/pkg/elastictv"
+)
+
+const testClientID = "test-client-id"
+
+// newTestServer serves the recorded replies in testdata for the Trakt API paths used by the
+// provider, checking the headers Trakt requires.
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	fixtures := map[string]string{
+		"/search/movie?page=1":             "testdata/search_movie_page1.json",
+		"/search/movie?page=2":             "testdata/search_movie_page2.json",
+		"/search/person?page=1":            "testdata/search_person.json",
+		"/search/tmdb/1396":                "testdata/search_tmdb_show.json",
+		"/search/imdb/tt0959621":           "testdata/search_imdb_episode.json",
+		"/people/3/movies":                 "testdata/person_movies.json",
+		"/movies/481":                      "testdata/movie.json",
+		"/movies/481/people":               "testdata/movie_people.json",
+		"/movies/481/aliases":              "testdata/movie_aliases.json",
+		"/shows/1388/seasons/1/episodes/1": "testdata/episode.json",
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("trakt-api-key") != testClientID || r.Header.Get("trakt-api-version") != apiVersion {
+			http.Error(w, "missing api headers", http.StatusUnauthorized)
+
+			return
+		}
+
+		key := r.URL.Path
+		if page := r.URL.Query().Get("page"); page != "" {
+			key += "?page=" + page
+			w.Header().Set("X-Pagination-Page-Count", "2")
+		}
+
+		// Movies are searched within elastictv.movie.year_range years of the year looked up
+		if r.URL.Path == "/search/movie" && r.URL.Query().Get("years") != "1998-2000" {
+			http.Error(w, "unexpected years", http.StatusBadRequest)
+
+			return
+		}
+
+		if r.URL.Path == "/movies/500" {
+			http.Error(w, "internal error", http.StatusInternalServerError)
+
+			return
+		}
+
+		fixture, ok := fixtures[key]
+		if !ok {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		data, err := os.ReadFile(fixture)
+		if err != nil {
+			t.Error(err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(data)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func newTestTrakt(t *testing.T, maxPages int) Trakt {
+	t.Helper()
+
+	return Trakt{
+		client:            newClient(newTestServer(t).URL, testClientID, maxPages),
+		aliasCountryCodes: []string{"fr", "gb"},
+	}
+}
+
+func TestSearch(t *testing.T) {
+	tests := []struct {
+		name     string
+		maxPages int
+		want     []int
+	}{
+		{"first page", 0, []int{481, 482}},
+		{"all pages", 5, []int{481, 482, 483}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			traktIDs, err := newTestTrakt(t, tt.maxPages).search(movieMediaType, "The Matrix", 1999)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(traktIDs, tt.want) {
+				t.Errorf("search() = %v, want %v", traktIDs, tt.want)
+			}
+		})
+	}
+}
+
+func TestLookup(t *testing.T) {
+	trakt := newTestTrakt(t, 0)
+
+	results, err := trakt.lookup("tmdb", showMediaType, 1396)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(results) != 1 || results[0].Show == nil || results[0].Show.IDs.Trakt != 1388 {
+		t.Errorf("lookup() = %+v, want show 1388", results)
+	}
+
+	// IDs which Trakt does not know are not an error
+	if results, err := trakt.lookup("tmdb", showMediaType, 1); err != nil || len(results) != 0 {
+		t.Errorf("lookup() of unknown ID = %+v, %v, want no results", results, err)
+	}
+}
+
+func TestSearchByCredits(t *testing.T) {
+	tests := []struct {
+		name     string
+		director bool
+		year     uint16
+		want     []int
+	}{
+		{"directing credits", true, 0, []int{481, 482}},
+		{"directing credits of year", true, 1999, []int{481}},
+		{"cast credits", false, 0, []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			traktIDs, err := newTestTrakt(t, 0).searchByCredits(movieMediaType, "Lana Wachowski", tt.director, tt.year)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(traktIDs, tt.want) {
+				t.Errorf("searchByCredits() = %v, want %v", traktIDs, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetMovie(t *testing.T) {
+	movie, err := newTestTrakt(t, 0).getMovie(481)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := elastictv.Title{
+		Title: "The Matrix",
+		Genre: []string{"Action", "Science Fiction"},
+		IDs:   elastictv.IDs{Trakt: 481, TraktSlug: "the-matrix-1999", TMDb: 603, IMDb: "tt0133093"},
+		Rating: elastictv.Ratings{{
+			Value:  8.7,
+			Source: "Trakt",
+		}},
+		Description: elastictv.Descriptions{{
+			Text: "Set in the 22nd century, The Matrix tells the story of a computer hacker who joins a group " +
+				"of underground insurgents fighting the vast and powerful computers who now rule the earth.",
+			Language: "en",
+			Source:   "Trakt",
+		}},
+		Year:    1999,
+		Tagline: "Welcome to the Real World.",
+		Credits: elastictv.Credits{
+			Actor:    []string{"Keanu Reeves", "Carrie-Anne Moss"},
+			Director: []string{"Lana Wachowski", "Lilly Wachowski"},
+			Other:    []string{"Joel Silver"},
+		},
+		Alias:  []string{"Matrix"},
+		Type:   elastictv.MovieType,
+		Source: "Trakt",
+	}
+
+	if !reflect.DeepEqual(movie, want) {
+		t.Errorf("movie = %+v, want %+v", movie, want)
+	}
+}
+
+func TestGetEpisode(t *testing.T) {
+	trakt := newTestTrakt(t, 0)
+
+	results, err := trakt.lookup("imdb", episodeMediaType, "tt0959621")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(results) != 1 || results[0].Show == nil || results[0].Episode == nil {
+		t.Fatalf("lookup() = %+v, want an episode with its show", results)
+	}
+
+	tvshow := *results[0].Show
+
+	episode, err := trakt.getEpisode(tvshow, results[0].Episode.Season, results[0].Episode.Number)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &elastictv.Episode{
+		AirDate:   "2008-01-20",
+		TVShowIDs: elastictv.IDs{Trakt: 1388, TraktSlug: "breaking-bad", TVDb: 81189, IMDb: "tt0903747", TMDb: 1396},
+		Description: elastictv.Descriptions{{
+			Text: "When an unassuming high school chemistry teacher discovers he has a rare form of lung cancer, " +
+				"he decides to team up with a former student.",
+			Language: "en",
+			Source:   "Trakt",
+		}},
+		EpisodeNo:  1,
+		SeasonNo:   1,
+		AbsoluteNo: 1,
+		IDs:        elastictv.IDs{Trakt: 73482, TVDb: 349232, IMDb: "tt0959621", TMDb: 62085},
+		Rating:     elastictv.Ratings{{Value: 8.3, Source: "Trakt"}},
+		Title:      "Pilot",
+		Source:     "Trakt",
+	}
+
+	if !reflect.DeepEqual(episode, want) {
+		t.Errorf("episode = %+v, want %+v", episode, want)
+	}
+
+	// The UTC date is not used when the timezone of the show is not known
+	tvshow.Airs.Timezone = ""
+	if episode, err := trakt.getEpisode(tvshow, 1, 1); err != nil || episode == nil || episode.AirDate != "" {
+		t.Errorf("getEpisode() without a timezone = %+v, %v, want no air date", episode, err)
+	}
+
+	// Episodes which Trakt does not list are not an error
+	if episode, err := trakt.getEpisode(tvshow, 9, 1); err != nil || episode != nil {
+		t.Errorf("getEpisode() of missing episode = %+v, %v, want nil", episode, err)
+	}
+}
+
+func TestClientErrors(t *testing.T) {
+	if _, err := newTestTrakt(t, 0).getMovie(500); err == nil {
+		t.Error("getMovie() of failing request returned no error")
+	}
+
+	unauthorized := Trakt{client: newClient(newTestServer(t).URL, "", 0)}
+	if _, err := unauthorized.search(movieMediaType, "The Matrix", 0); err == nil {
+		t.Error("search() without client ID returned no error")
+	}
+}
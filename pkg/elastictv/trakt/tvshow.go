@@ -0,0 +1,140 @@
+package trakt
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/shaunschembri/elastictv/pkg/elastictv"
+)
+
+func (t Trakt) SearchTvShows(params elastictv.SearchItem) error {
+	switch params.Attribute {
+	case elastictv.TitleSearchAttribute:
+		return t.searchTVShowByTitle(params.Query)
+	case elastictv.DirectorSearchAttribute:
+		return t.searchTVShowByCredits(params.Query, true)
+	case elastictv.ActorSearchAttribute:
+		return t.searchTVShowByCredits(params.Query, false)
+	case elastictv.IMDbIDSearchAttribute:
+		return t.searchTVShowByExternalID("imdb", params.Query)
+	case elastictv.TMDbIDSearchAttribute:
+		return t.searchTVShowByExternalID("tmdb", params.Query)
+	case elastictv.TVDbIDSearchAttribute:
+		return t.searchTVShowByExternalID("tvdb", params.Query)
+	case elastictv.TraktIDSearchAttribute:
+		return t.searchTVShowByExternalID("trakt", params.Query)
+	default:
+		return elastictv.NewNotSupportedError(t.Name(), params)
+	}
+}
+
+func (t Trakt) searchTVShowByTitle(tvshowTitle any) error {
+	title, ok := tvshowTitle.(string)
+	if !ok {
+		return fmt.Errorf("%s: cannot convert query item [ %s ] to tv show title", t.Name(), tvshowTitle)
+	}
+
+	log.Printf("%s: Searching for tvshow by title [ %s ]", t.Name(), title)
+
+	traktIDs, err := t.search(showMediaType, title, 0)
+	if err != nil {
+		return fmt.Errorf("%s: error searching tvshow title [ %s ]: %w", t.Name(), title, err)
+	}
+
+	return t.getTVShowsDetails(traktIDs)
+}
+
+func (t Trakt) searchTVShowByCredits(personName any, director bool) error {
+	name, ok := personName.(string)
+	if !ok {
+		return fmt.Errorf("%s: cannot convert query item [ %s ] to person name", t.Name(), personName)
+	}
+
+	log.Printf("%s: Searching for tvshow credits [ %s ]", t.Name(), name)
+
+	traktIDs, err := t.searchByCredits(showMediaType, name, director, 0)
+	if err != nil {
+		return err
+	}
+
+	return t.getTVShowsDetails(traktIDs)
+}
+
+func (t Trakt) searchTVShowByExternalID(source string, externalID any) error {
+	log.Printf("%s: Searching for tvshow by %s ID [ %v ]", t.Name(), source, externalID)
+
+	results, err := t.lookup(source, showMediaType, externalID)
+	if err != nil {
+		return fmt.Errorf("%s: error searching tvshow by %s ID [ %v ]: %w", t.Name(), source, externalID, err)
+	}
+
+	traktIDs := make([]int, 0)
+	for _, result := range results {
+		if result.Show != nil {
+			traktIDs = append(traktIDs, result.Show.IDs.Trakt)
+		}
+	}
+
+	return t.getTVShowsDetails(traktIDs)
+}
+
+func (t Trakt) getTVShowsDetails(traktIDs []int) error {
+	var errors *multierror.Error
+
+	for _, traktID := range traktIDs {
+		if err := t.getTVShowDetails(traktID); err != nil {
+			errors = multierror.Append(errors, err)
+		}
+	}
+
+	return errors.ErrorOrNil()
+}
+
+func (t Trakt) getTVShowDetails(traktID int) error {
+	query := elastictv.NewQuery().WithTraktID(traktID).WithType(elastictv.TvShowType)
+	if !t.estv.IsRecordExpired(query, t.estv.Index.Title) {
+		return nil
+	}
+
+	tvshow, err := t.getTVShow(traktID)
+	if err != nil {
+		return err
+	}
+
+	if err := t.estv.UpsertTitle(tvshow); err != nil {
+		return fmt.Errorf("error indexing tvshow: %w", err)
+	}
+
+	return nil
+}
+
+// getTVShow gets the details of a tv show with its credits and aliases.
+func (t Trakt) getTVShow(traktID int) (elastictv.Title, error) {
+	details := show{}
+	if _, err := t.client.get(fmt.Sprintf("/shows/%d", traktID), url.Values{"extended": {"full"}}, &details); err != nil {
+		return elastictv.Title{}, fmt.Errorf("%s: error getting details for ID %d: %w", t.Name(), traktID, err)
+	}
+
+	log.Printf("%s: Got details for tvshow [ %s ]", t.Name(), details.Title)
+
+	credits, err := t.getPeople(showMediaType, traktID)
+	if err != nil {
+		return elastictv.Title{}, err
+	}
+
+	return elastictv.Title{
+		Title:       details.Title,
+		Genre:       t.getGenres(details.Genres),
+		IDs:         t.getIDs(details.IDs),
+		Rating:      t.getRating(details.Rating),
+		Description: t.getDescription(details.Overview),
+		Year:        uint16(details.Year),
+		Credits:     credits,
+		Alias:       t.getAliases(showMediaType, traktID, details.Title),
+		Type:        elastictv.TvShowType,
+		Source:      t.Name(),
+	}, nil
+}
@@ -0,0 +1,74 @@
+package tvmaze
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	defaultBaseURL  = "https://api.tvmaze.com"
+	requestTimeout  = 30 * time.Second
+	maxRetries      = 5
+	rateLimitPeriod = 10 * time.Second
+)
+
+var errNotFound = errors.New("not found")
+
+type client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+func newClient(baseURL string) client {
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
+
+	return client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: requestTimeout},
+	}
+}
+
+// get requests the given path and decodes the JSON reply into result. TVmaze rate limits
+// requests per IP, so requests which are rejected with status 429 are retried.
+func (c client) get(path string, params url.Values, result interface{}) error {
+	requestURL := c.baseURL + path
+	if len(params) > 0 {
+		requestURL += "?" + params.Encode()
+	}
+
+	for retry := 0; ; retry++ {
+		response, err := c.httpClient.Get(requestURL)
+		if err != nil {
+			return fmt.Errorf("error requesting %s: %w", requestURL, err)
+		}
+
+		if response.StatusCode == http.StatusTooManyRequests && retry < maxRetries {
+			response.Body.Close()
+			time.Sleep(rateLimitPeriod)
+
+			continue
+		}
+
+		defer response.Body.Close()
+
+		switch {
+		case response.StatusCode == http.StatusNotFound:
+			return errNotFound
+		case response.StatusCode != http.StatusOK:
+			return fmt.Errorf("request to %s returned status %s", requestURL, response.Status)
+		}
+
+		if err := json.NewDecoder(response.Body).Decode(result); err != nil {
+			return fmt.Errorf("error parsing reply from %s: %w", requestURL, err)
+		}
+
+		return nil
+	}
+}
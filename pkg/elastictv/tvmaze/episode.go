@@ -0,0 +1,93 @@
+package tvmaze
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strconv"
+
+	"github.com/shaunschembri/elastictv/pkg/elastictv"
+)
+
+// SearchEpisode gets an episode by its season and episode number. TVmaze does not list
+// TMDb IDs, so the tv show is resolved from the title index using its IMDb or TheTVDB ID.
+func (t TVmaze) SearchEpisode(searchItem elastictv.SearchItem) error {
+	if searchItem.Attribute != elastictv.TMDbIDSearchAttribute ||
+		searchItem.Type != elastictv.EpisodeType ||
+		searchItem.SeasonNo == 0 ||
+		searchItem.EpisodeNo == 0 {
+		return elastictv.NewNotSupportedError(t.Name(), searchItem)
+	}
+
+	tmdbID, ok := searchItem.Query.(int)
+	if !ok {
+		return fmt.Errorf("%s: cannot convert query item [ %s ] to TMDb ID", t.Name(), searchItem.Query)
+	}
+
+	tvshow := elastictv.Title{}
+	query := elastictv.NewQuery().WithTMDbID(tmdbID).WithType(elastictv.TvShowType)
+
+	if _, err := t.estv.GetRecord(query, t.estv.Index.Title, &tvshow); err != nil {
+		return fmt.Errorf("%s: error getting tvshow for episode [ %s ] : %w", t.Name(), searchItem, err)
+	}
+
+	tvmazeID, err := t.getTVShowID(tvshow)
+	if err != nil || tvmazeID == 0 {
+		return err
+	}
+
+	log.Printf("%s: Getting details for episode [ %s ]", t.Name(), searchItem)
+
+	tvshowIDs := tvshow.IDs
+	tvshowIDs.TVmaze = tvmazeID
+
+	episode, err := t.getEpisode(tvshowIDs, searchItem.SeasonNo, searchItem.EpisodeNo)
+	if err != nil {
+		return fmt.Errorf("%s: error getting details for episode [ %s ] : %w", t.Name(), searchItem, err)
+	}
+
+	if episode == nil {
+		return nil
+	}
+
+	if err := t.estv.UpsertEpisode(*episode); err != nil {
+		return fmt.Errorf("%s: error indexing episode [ %s ] : %w", t.Name(), episode.Title, err)
+	}
+
+	return nil
+}
+
+// getEpisode gets an episode of the tv show with the TVmaze ID in tvshowIDs by its season and
+// episode number, returning nil if TVmaze does not list the episode.
+func (t TVmaze) getEpisode(tvshowIDs elastictv.IDs, seasonNo, episodeNo uint16) (*elastictv.Episode, error) {
+	details := episode{}
+	params := url.Values{
+		"season": {strconv.Itoa(int(seasonNo))},
+		"number": {strconv.Itoa(int(episodeNo))},
+	}
+
+	err := t.client.get(fmt.Sprintf("/shows/%d/episodebynumber", tvshowIDs.TVmaze), params, &details)
+	if errors.Is(err, errNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &elastictv.Episode{
+		AirDate:     details.AirDate,
+		TVShowIDs:   tvshowIDs,
+		Description: t.getDescription(details.Summary),
+		EpisodeNo:   uint16(details.Number),
+		SeasonNo:    uint16(details.Season),
+		Image:       t.getImage(details.Image),
+		IDs: elastictv.IDs{
+			TVmaze: details.ID,
+		},
+		Rating: t.getRating(details.Rating),
+		Title:  details.Name,
+		Source: t.Name(),
+	}, nil
+}
@@ -0,0 +1,83 @@
+package tvmaze
+
+type show struct {
+	ID        int      `json:"id"`
+	Name      string   `json:"name"`
+	Language  string   `json:"language"`
+	Genres    []string `json:"genres"`
+	Premiered string   `json:"premiered"`
+	Summary   string   `json:"summary"`
+	Rating    rating   `json:"rating"`
+	Image     *image   `json:"image"`
+	Network   *network `json:"network"`
+	Channel   *network `json:"webChannel"`
+	Externals struct {
+		TVDb int    `json:"thetvdb"`
+		IMDb string `json:"imdb"`
+	} `json:"externals"`
+	Embedded struct {
+		Cast []struct {
+			Person person `json:"person"`
+		} `json:"cast"`
+		Crew []struct {
+			Type   string `json:"type"`
+			Person person `json:"person"`
+		} `json:"crew"`
+		Akas []struct {
+			Name    string   `json:"name"`
+			Country *country `json:"country"`
+		} `json:"akas"`
+	} `json:"_embedded"`
+}
+
+type episode struct {
+	ID      int    `json:"id"`
+	Name    string `json:"name"`
+	Season  int    `json:"season"`
+	Number  int    `json:"number"`
+	AirDate string `json:"airdate"`
+	Summary string `json:"summary"`
+	Rating  rating `json:"rating"`
+	Image   *image `json:"image"`
+}
+
+type rating struct {
+	Average float32 `json:"average"`
+}
+
+type image struct {
+	Medium   string `json:"medium"`
+	Original string `json:"original"`
+}
+
+type network struct {
+	Name    string   `json:"name"`
+	Country *country `json:"country"`
+}
+
+type country struct {
+	Name string `json:"name"`
+	Code string `json:"code"`
+}
+
+type person struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type showSearchResult struct {
+	Score float64 `json:"score"`
+	Show  show    `json:"show"`
+}
+
+type personSearchResult struct {
+	Score  float64 `json:"score"`
+	Person person  `json:"person"`
+}
+
+type credit struct {
+	Type     string `json:"type"`
+	Embedded struct {
+		Show show `json:"show"`
+	} `json:"_embedded"`
+}
@@ -0,0 +1,165 @@
+package tvmaze
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/viper"
+
+	"github.com/shaunschembri/elastictv/pkg/elastictv"
+)
+
+const (
+	maxActors = 10
+	// TVmaze only lists summaries in English
+	descriptionLanguage = "en"
+)
+
+var (
+	htmlTagsRegexp    = regexp.MustCompile(`<[^>]*>`)
+	directorCrewTypes = []string{"Director", "Creator"}
+	otherCrewTypes    = []string{"Producer", "Executive Producer", "Developer"}
+)
+
+type TVmaze struct {
+	estv   *elastictv.ElasticTV
+	client client
+	// List of country codes to use titles from
+	aliasCountryCodes []string
+}
+
+func (t TVmaze) Name() string {
+	return "TVmaze"
+}
+
+func (t TVmaze) Init(estv *elastictv.ElasticTV) (elastictv.SearchableProvider, error) {
+	t.client = newClient(viper.GetString("elastictv.provider.tvmaze.base_url"))
+	t.aliasCountryCodes = viper.GetStringSlice("elastictv.provider.tvmaze.alias_countries")
+	t.estv = estv
+
+	return t, nil
+}
+
+func (t TVmaze) Capabilities() elastictv.Capabilities {
+	return elastictv.Capabilities{
+		{Type: elastictv.TvShowType, Attributes: []elastictv.SearchAttribute{
+			elastictv.TitleSearchAttribute, elastictv.DirectorSearchAttribute, elastictv.ActorSearchAttribute,
+			elastictv.IMDbIDSearchAttribute, elastictv.TVDbIDSearchAttribute, elastictv.TVmazeIDSearchAttribute,
+		}},
+		{Type: elastictv.EpisodeType, Attributes: []elastictv.SearchAttribute{
+			elastictv.TMDbIDSearchAttribute,
+		}},
+	}
+}
+
+// SearchMovies is not supported since TVmaze only lists tv shows.
+func (t TVmaze) SearchMovies(params elastictv.SearchItem) error {
+	return elastictv.NewNotSupportedError(t.Name(), params)
+}
+
+func (t TVmaze) getYear(date string) uint16 {
+	if len(date) < 4 {
+		return 0
+	}
+
+	year, _ := strconv.Atoi(date[:4])
+
+	return uint16(year)
+}
+
+func (t TVmaze) getImage(img *image) string {
+	if img == nil {
+		return ""
+	}
+
+	return img.Original
+}
+
+func (t TVmaze) getDescription(summary string) elastictv.Descriptions {
+	text := strings.TrimSpace(html.UnescapeString(htmlTagsRegexp.ReplaceAllString(summary, "")))
+	if text == "" {
+		return nil
+	}
+
+	return elastictv.Descriptions{{
+		Text:     text,
+		Language: descriptionLanguage,
+		Source:   t.Name(),
+	}}
+}
+
+func (t TVmaze) getRating(r rating) elastictv.Ratings {
+	if r.Average > 0 {
+		return elastictv.Ratings{{
+			Value:  r.Average,
+			Source: t.Name(),
+		}}
+	}
+
+	return nil
+}
+
+func (t TVmaze) getCountries(details show) []string {
+	for _, n := range []*network{details.Network, details.Channel} {
+		if n != nil && n.Country != nil && n.Country.Name != "" {
+			return []string{n.Country.Name}
+		}
+	}
+
+	return nil
+}
+
+func (t TVmaze) getCredits(details show) elastictv.Credits {
+	credits := elastictv.Credits{}
+
+	for _, cast := range details.Embedded.Cast {
+		if len(credits.Actor) < maxActors {
+			credits.Actor = append(credits.Actor, cast.Person.Name)
+		}
+	}
+
+	for _, crew := range details.Embedded.Crew {
+		switch {
+		case t.contains(directorCrewTypes, crew.Type):
+			credits.Director = append(credits.Director, crew.Person.Name)
+		case t.contains(otherCrewTypes, crew.Type):
+			credits.Other = append(credits.Other, crew.Person.Name)
+		}
+	}
+
+	return credits
+}
+
+func (t TVmaze) getAliases(details show) []string {
+	aliases := make([]string, 0)
+
+	for _, aka := range details.Embedded.Akas {
+		if aka.Name == "" || aka.Country == nil {
+			continue
+		}
+		// Check if alias country is in the required list
+		if !t.contains(t.aliasCountryCodes, aka.Country.Code) {
+			continue
+		}
+		// Check if name is not the title or already in aliases
+		if strings.EqualFold(details.Name, aka.Name) || t.contains(aliases, aka.Name) {
+			continue
+		}
+
+		aliases = append(aliases, aka.Name)
+	}
+
+	return aliases
+}
+
+func (t TVmaze) contains(stringSlice []string, value string) bool {
+	for _, n := range stringSlice {
+		if strings.EqualFold(n, value) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,199 @@
+package tvmaze
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/shaunschembri/elastictv/pkg/elastictv"
+)
+
+// newTestServer serves the fixtures in testdata for the TVmaze API paths used by the provider.
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	fixtures := map[string]string{
+		"/shows/169":                 "testdata/show.json",
+		"/shows/169/episodebynumber": "testdata/episode.json",
+		"/lookup/shows":              "testdata/show.json",
+		"/search/shows":              "testdata/search_shows.json",
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+
+		switch {
+		case r.URL.Path == "/shows/500":
+			http.Error(w, "internal error", http.StatusInternalServerError)
+
+			return
+		case r.URL.Path == "/shows/501":
+			w.Write([]byte("{"))
+
+			return
+		case r.URL.Path == "/shows/169/episodebynumber" && query.Get("season") != "1":
+			http.NotFound(w, r)
+
+			return
+		case r.URL.Path == "/lookup/shows" && query.Get("imdb") != "tt0903747" && query.Get("thetvdb") != "81189":
+			http.NotFound(w, r)
+
+			return
+		}
+
+		fixture, ok := fixtures[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		data, err := os.ReadFile(fixture)
+		if err != nil {
+			t.Error(err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(data)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func newTestTVmaze(t *testing.T) TVmaze {
+	t.Helper()
+
+	return TVmaze{
+		client:            newClient(newTestServer(t).URL + "/"),
+		aliasCountryCodes: []string{"RU", "DE"},
+	}
+}
+
+func TestGetTVShow(t *testing.T) {
+	tvshow, err := newTestTVmaze(t).getTVShow(169)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := elastictv.Title{
+		Title: "Breaking Bad",
+		Genre: []string{"Drama", "Crime", "Thriller"},
+		IDs:   elastictv.IDs{TVmaze: 169, TVDb: 81189, IMDb: "tt0903747"},
+		Rating: elastictv.Ratings{{
+			Value:  9.2,
+			Source: "TVmaze",
+		}},
+		Image: "https://static.tvmaze.com/uploads/images/original_untouched/501/1253519.jpg",
+		Description: elastictv.Descriptions{{
+			Text: "Breaking Bad follows protagonist Walter White, a chemistry teacher who lives in New Mexico " +
+				"with his wife and teenage son who has cerebral palsy.",
+			Language: "en",
+			Source:   "TVmaze",
+		}},
+		Year:     2008,
+		Country:  []string{"United States"},
+		Language: "English",
+		Credits: elastictv.Credits{
+			Actor:    []string{"Bryan Cranston", "Aaron Paul"},
+			Director: []string{"Vince Gilligan"},
+			Other:    []string{"Mark Johnson"},
+		},
+		Alias:  []string{"Во все тяжкие"},
+		Type:   elastictv.TvShowType,
+		Source: "TVmaze",
+	}
+
+	if !reflect.DeepEqual(tvshow, want) {
+		t.Errorf("tvshow = %+v, want %+v", tvshow, want)
+	}
+}
+
+func TestGetEpisode(t *testing.T) {
+	tvmaze := newTestTVmaze(t)
+	tvshowIDs := elastictv.IDs{TVmaze: 169, IMDb: "tt0903747"}
+
+	episode, err := tvmaze.getEpisode(tvshowIDs, 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &elastictv.Episode{
+		AirDate:   "2008-01-20",
+		TVShowIDs: tvshowIDs,
+		Description: elastictv.Descriptions{{
+			Text: "When an unassuming high school chemistry teacher discovers he has a rare form of lung cancer, " +
+				"he decides to team up with a former student.",
+			Language: "en",
+			Source:   "TVmaze",
+		}},
+		EpisodeNo: 1,
+		SeasonNo:  1,
+		Image:     "https://static.tvmaze.com/uploads/images/original_untouched/12/30117.jpg",
+		IDs:       elastictv.IDs{TVmaze: 12192},
+		Rating:    elastictv.Ratings{{Value: 8.2, Source: "TVmaze"}},
+		Title:     "Pilot",
+		Source:    "TVmaze",
+	}
+
+	if !reflect.DeepEqual(episode, want) {
+		t.Errorf("episode = %+v, want %+v", episode, want)
+	}
+
+	// Episodes which TVmaze does not list are not an error
+	if episode, err := tvmaze.getEpisode(tvshowIDs, 9, 1); err != nil || episode != nil {
+		t.Errorf("getEpisode() of missing episode = %+v, %v, want nil", episode, err)
+	}
+}
+
+func TestGetTVShowID(t *testing.T) {
+	tvmaze := newTestTVmaze(t)
+
+	tests := []struct {
+		name string
+		ids  elastictv.IDs
+		want int
+	}{
+		{"TVmaze ID", elastictv.IDs{TVmaze: 82}, 82},
+		{"IMDb ID", elastictv.IDs{IMDb: "tt0903747"}, 169},
+		{"TheTVDB ID", elastictv.IDs{IMDb: "tt0000001", TVDb: 81189}, 169},
+		{"unknown IDs", elastictv.IDs{IMDb: "tt0000001"}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := tvmaze.getTVShowID(elastictv.Title{IDs: tt.ids})
+			if err != nil || id != tt.want {
+				t.Errorf("getTVShowID() = %d, %v, want %d", id, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestClientSearch(t *testing.T) {
+	results := make([]showSearchResult, 0)
+	if err := newTestTVmaze(t).client.get("/search/shows", nil, &results); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(results) != 2 || results[0].Show.ID != 169 || results[1].Show.ID != 33320 {
+		t.Errorf("results = %+v, want shows 169 and 33320", results)
+	}
+}
+
+func TestClientErrors(t *testing.T) {
+	tvmaze := newTestTVmaze(t)
+
+	if err := tvmaze.client.get("/shows/404", nil, &show{}); !errors.Is(err, errNotFound) {
+		t.Errorf("get() of missing show = %v, want errNotFound", err)
+	}
+
+	for _, id := range []int{500, 501} {
+		if _, err := tvmaze.getTVShow(id); err == nil || errors.Is(err, errNotFound) {
+			t.Errorf("getTVShow(%d) = %v, want an error", id, err)
+		}
+	}
+}
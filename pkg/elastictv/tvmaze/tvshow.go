@@ -0,0 +1,204 @@
+package tvmaze
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strconv"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/shaunschembri/elastictv/pkg/elastictv"
+)
+
+func (t TVmaze) SearchTvShows(params elastictv.SearchItem) error {
+	switch params.Attribute {
+	case elastictv.TitleSearchAttribute:
+		return t.searchTVShowByTitle(params.Query)
+	case elastictv.DirectorSearchAttribute:
+		return t.searchTVShowByCredits(params.Query, "crewcredits")
+	case elastictv.ActorSearchAttribute:
+		return t.searchTVShowByCredits(params.Query, "castcredits")
+	case elastictv.IMDbIDSearchAttribute:
+		return t.lookupTVShow("imdb", params.Query)
+	case elastictv.TVDbIDSearchAttribute:
+		return t.lookupTVShow("thetvdb", params.Query)
+	case elastictv.TVmazeIDSearchAttribute:
+		tvmazeID, ok := params.Query.(int)
+		if !ok {
+			return fmt.Errorf("%s: cannot convert query item [ %s ] to TVmaze ID", t.Name(), params.Query)
+		}
+
+		return t.getTVShowDetails(tvmazeID)
+	default:
+		return elastictv.NewNotSupportedError(t.Name(), params)
+	}
+}
+
+func (t TVmaze) searchTVShowByTitle(tvshowTitle any) error {
+	title, ok := tvshowTitle.(string)
+	if !ok {
+		return fmt.Errorf("%s: cannot convert query item [ %s ] to tv show title", t.Name(), tvshowTitle)
+	}
+
+	log.Printf("%s: Searching for tvshow by title [ %s ]", t.Name(), title)
+
+	results := make([]showSearchResult, 0)
+	if err := t.client.get("/search/shows", url.Values{"q": {title}}, &results); err != nil {
+		return fmt.Errorf("%s: error searching tvshow title [%s]: %w", t.Name(), title, err)
+	}
+
+	var errors *multierror.Error
+	for _, result := range results {
+		if err := t.getTVShowDetails(result.Show.ID); err != nil {
+			errors = multierror.Append(errors, err)
+		}
+	}
+
+	return errors.ErrorOrNil()
+}
+
+func (t TVmaze) searchTVShowByCredits(personName any, creditsType string) error {
+	name, ok := personName.(string)
+	if !ok {
+		return fmt.Errorf("%s: cannot convert query item [ %s ] to person name", t.Name(), personName)
+	}
+
+	log.Printf("%s: Searching for tvshow %s [ %s ]", t.Name(), creditsType, name)
+
+	persons := make([]personSearchResult, 0)
+	if err := t.client.get("/search/people", url.Values{"q": {name}}, &persons); err != nil {
+		return fmt.Errorf("%s: error searching for person [%s]: %w", t.Name(), name, err)
+	}
+
+	var errors *multierror.Error
+	for _, result := range persons {
+		credits := make([]credit, 0)
+
+		path := fmt.Sprintf("/people/%d/%s", result.Person.ID, creditsType)
+		if err := t.client.get(path, url.Values{"embed": {"show"}}, &credits); err != nil {
+			errors = multierror.Append(errors,
+				fmt.Errorf("%s: error searching %s for person [%s]: %w",
+					t.Name(), creditsType, result.Person.Name, err))
+
+			continue
+		}
+
+		for _, c := range credits {
+			if c.Type != "" && !t.contains(directorCrewTypes, c.Type) {
+				continue
+			}
+
+			if err := t.getTVShowDetails(c.Embedded.Show.ID); err != nil {
+				errors = multierror.Append(errors, err)
+			}
+		}
+	}
+
+	return errors.ErrorOrNil()
+}
+
+func (t TVmaze) lookupTVShow(source string, externalID any) error {
+	id := fmt.Sprintf("%v", externalID)
+
+	log.Printf("%s: Looking up tvshow by %s ID [ %s ]", t.Name(), source, id)
+
+	details := show{}
+
+	err := t.client.get("/lookup/shows", url.Values{source: {id}}, &details)
+	if errors.Is(err, errNotFound) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("%s: error looking up tvshow by %s ID [ %s ]: %w", t.Name(), source, id, err)
+	}
+
+	return t.getTVShowDetails(details.ID)
+}
+
+// getTVShowID returns the TVmaze ID of a tv show which is already indexed, resolving it
+// by its IMDb or TheTVDB ID if it was indexed by another provider.
+func (t TVmaze) getTVShowID(tvshow elastictv.Title) (int, error) {
+	if tvshow.IDs.TVmaze > 0 {
+		return tvshow.IDs.TVmaze, nil
+	}
+
+	lookups := make([][2]string, 0)
+	if tvshow.IDs.IMDb != "" {
+		lookups = append(lookups, [2]string{"imdb", tvshow.IDs.IMDb})
+	}
+
+	if tvshow.IDs.TVDb > 0 {
+		lookups = append(lookups, [2]string{"thetvdb", strconv.Itoa(tvshow.IDs.TVDb)})
+	}
+
+	for _, lookup := range lookups {
+		details := show{}
+
+		err := t.client.get("/lookup/shows", url.Values{lookup[0]: {lookup[1]}}, &details)
+		if errors.Is(err, errNotFound) {
+			continue
+		}
+
+		if err != nil {
+			return 0, fmt.Errorf("%s: error looking up tvshow [ %s ]: %w", t.Name(), tvshow.Title, err)
+		}
+
+		return details.ID, nil
+	}
+
+	return 0, nil
+}
+
+func (t TVmaze) getTVShowDetails(tvmazeID int) error {
+	query := elastictv.NewQuery().WithTVmazeID(tvmazeID).WithType(elastictv.TvShowType)
+	if !t.estv.IsRecordExpired(query, t.estv.Index.Title) {
+		return nil
+	}
+
+	tvshow, err := t.getTVShow(tvmazeID)
+	if err != nil {
+		return err
+	}
+
+	if err := t.estv.UpsertTitle(tvshow); err != nil {
+		return fmt.Errorf("error indexing tvshow: %w", err)
+	}
+
+	return nil
+}
+
+// getTVShow gets the details of a tv show with its cast, crew and aliases.
+func (t TVmaze) getTVShow(tvmazeID int) (elastictv.Title, error) {
+	details := show{}
+	path := fmt.Sprintf("/shows/%d", tvmazeID)
+	params := url.Values{"embed[]": {"cast", "crew", "akas"}}
+
+	if err := t.client.get(path, params, &details); err != nil {
+		return elastictv.Title{}, fmt.Errorf("%s: error getting details for ID %d: %w", t.Name(), tvmazeID, err)
+	}
+
+	log.Printf("%s: Got details for tvshow [ %s ]", t.Name(), details.Name)
+
+	return elastictv.Title{
+		Title: details.Name,
+		Genre: details.Genres,
+		IDs: elastictv.IDs{
+			TVmaze: details.ID,
+			TVDb:   details.Externals.TVDb,
+			IMDb:   details.Externals.IMDb,
+		},
+		Rating:      t.getRating(details.Rating),
+		Image:       t.getImage(details.Image),
+		Description: t.getDescription(details.Summary),
+		Year:        t.getYear(details.Premiered),
+		Country:     t.getCountries(details),
+		Language:    details.Language,
+		Credits:     t.getCredits(details),
+		Alias:       t.getAliases(details),
+		Type:        elastictv.TvShowType,
+		Source:      t.Name(),
+	}, nil
+}
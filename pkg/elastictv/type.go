@@ -10,14 +10,16 @@ const (
 	MovieType Type = iota + 1
 	TvShowType
 	EpisodeType
+	SeasonType
 )
 
-var typesList = [...]string{"movie", "tv", "episode"}
+var typesList = [...]string{"movie", "tv", "episode", "season"}
 
 var typesMap = map[string]Type{
 	"movie":   MovieType,
 	"tv":      TvShowType,
 	"episode": EpisodeType,
+	"season":  SeasonType,
 }
 
 func (t Type) MarshalText() ([]byte, error) {